@@ -0,0 +1,46 @@
+package main
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// backoff computes exponentially growing delays with full jitter.
+// The zero value is not usable, use newBackoff instead.
+type backoff struct {
+	min     time.Duration
+	max     time.Duration
+	attempt int
+}
+
+// newBackoff creates a backoff starting at min and capped at max.
+func newBackoff(min, max time.Duration) *backoff {
+	return &backoff{
+		min: min,
+		max: max,
+	}
+}
+
+// next returns the delay to wait before the next attempt.
+// The delay is drawn uniformly in [min, min * 2^attempt], capped at max.
+func (b *backoff) next() time.Duration {
+	ceiling := b.max
+	if b.attempt < 32 {
+		if exp := b.min << b.attempt; exp > 0 && exp < b.max {
+			ceiling = exp
+		}
+	}
+
+	b.attempt++
+
+	if ceiling <= b.min {
+		return b.min
+	}
+
+	return b.min + time.Duration(rand.Int64N(int64(ceiling-b.min)+1))
+}
+
+// reset restarts the backoff sequence from the minimum delay.
+func (b *backoff) reset() {
+	b.attempt = 0
+}
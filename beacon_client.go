@@ -5,10 +5,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,16 +19,47 @@ const (
 	defaultTimeout = 12 * time.Second
 
 	blockEvent = "block"
+
+	sseReconnectMinBackoff = 500 * time.Millisecond
+	sseReconnectMaxBackoff = 30 * time.Second
+
+	// maxBackfillSlots bounds how many slots are replayed after a reconnection.
+	maxBackfillSlots = 64
+
+	// Encodings blocks can be fetched with.
+	blockEncodingSSZ  = "ssz"
+	blockEncodingJSON = "json"
+
+	sszMediaType  = "application/octet-stream"
+	jsonMediaType = "application/json"
+
+	consensusVersionHeader = "Eth-Consensus-Version"
+)
+
+var (
+	errBlockNotFound = fmt.Errorf("block %w", errNotFound)
+
+	// errSSZUnavailable is returned when a block fetched as SSZ cannot be decoded, and should be fetched as JSON instead.
+	errSSZUnavailable = errors.New("SSZ block unavailable")
+
+	// blindedBlockForks are the forks whose blinded blocks decode as SignedBlindedBeaconBlock.
+	blindedBlockForks = map[string]bool{
+		"electra": true,
+		"fulu":    true,
+	}
 )
 
 // BeaconClient is an HTTP client for interacting with a beacon node.
 type BeaconClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL       string
+	httpClient    *http.Client
+	blockEncoding string
+	retry         retryPolicy
+	sseConnected  atomic.Bool
 }
 
-// NewBeaconClient creates a new beacon node client.
-func NewBeaconClient(baseURL string) *BeaconClient {
+// NewBeaconClient creates a new beacon node client, retrying failed requests with retry.
+func NewBeaconClient(baseURL string, retry retryPolicy) *BeaconClient {
 	// Ensure no trailing slash
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
@@ -34,148 +68,362 @@ func NewBeaconClient(baseURL string) *BeaconClient {
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		blockEncoding: blockEncodingSSZ,
+		retry:         retry,
 	}
 }
 
-// subscribeToBlockGossip subscribes to block SSE events.
-// Returns a channel that receives BlockEvent and an error channel.
-func (c *BeaconClient) subscribeToBlockGossip(ctx context.Context) (<-chan BlockEventData, <-chan error) {
-	events := make(chan BlockEventData)
-	errs := make(chan error, 1)
+// URL returns the base URL of the beacon node.
+func (c *BeaconClient) URL() string {
+	return c.baseURL
+}
 
-	go func() {
-		var (
-			eventType string
-			data      string
-		)
+// parseBeaconClients creates one beacon client per comma-separated URL.
+func parseBeaconClients(urls string, retry retryPolicy) []*BeaconClient {
+	var clients []*BeaconClient
+	for url := range strings.SplitSeq(urls, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
 
-		defer close(events)
-		defer close(errs)
+		clients = append(clients, NewBeaconClient(url, retry))
+	}
 
-		url := c.baseURL + "/eth/v1/events?topics=" + blockEvent
+	return clients
+}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-		if err != nil {
-			errs <- fmt.Errorf("failed to create request: %w", err)
-			return
-		}
-		req.Header.Set("Accept", "text/event-stream")
+// streamBlockGossip connects once to the block SSE stream and forwards every
+// block event to onEvent until the stream breaks. onConnect is called once the
+// stream is established, before any event is read.
+func (c *BeaconClient) streamBlockGossip(ctx context.Context, onConnect func() error, onEvent func(BlockEventData) error) error {
+	var (
+		eventType string
+		data      string
+	)
 
-		// Use a client without timeout for SSE
-		sseClient := &http.Client{}
-		resp, err := sseClient.Do(req)
-		if err != nil {
-			errs <- fmt.Errorf("failed to connect to SSE stream: %w", err)
-			return
-		}
-		defer resp.Body.Close()
+	url := c.baseURL + "/eth/v1/events?topics=" + blockEvent
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			errs <- fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
-			return
-		}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
 
-		logger.Info("Connected to SSE stream", "event", blockEvent, "url", url)
+	// Use a client without timeout for SSE
+	sseClient := &http.Client{}
+	resp, err := sseClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SSE stream: %w", err)
+	}
+	defer resp.Body.Close()
 
-		scanner := bufio.NewScanner(resp.Body)
-		for scanner.Scan() {
-			line := scanner.Text()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
 
-			if line != "" {
-				switch {
-				case strings.HasPrefix(line, "event:"):
-					eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
-				case strings.HasPrefix(line, "data:"):
-					data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
-				}
-				continue
-			}
+	logger.Info("Connected to SSE stream", "event", blockEvent, "url", url)
 
-			// End of event, process if we have data
-			if eventType != blockEvent || data == "" {
-				eventType = ""
-				data = ""
-				continue
-			}
+	c.sseConnected.Store(true)
+	defer c.sseConnected.Store(false)
 
-			var eventData BlockEventData
-			if err := json.Unmarshal([]byte(data), &eventData); err != nil {
-				logger.Warn("Failed to parse event", "event", blockEvent, "error", err, "data", data)
-				eventType = ""
-				data = ""
-				continue
-			}
+	if err := onConnect(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
 
-			select {
-			case events <- eventData:
-			case <-ctx.Done():
-				return
+		if line != "" {
+			switch {
+			case strings.HasPrefix(line, "event:"):
+				eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
 			}
+			continue
+		}
+
+		// End of event, process if we have data
+		if eventType != blockEvent || data == "" {
+			eventType = ""
+			data = ""
+			continue
+		}
 
+		var eventData BlockEventData
+		if err := json.Unmarshal([]byte(data), &eventData); err != nil {
+			logger.Warn("Failed to parse event", "event", blockEvent, "error", err, "data", data)
 			eventType = ""
 			data = ""
+			continue
+		}
+		eventData.receivedAt = time.Now()
+
+		if err := onEvent(eventData); err != nil {
+			return err
 		}
 
-		if err := scanner.Err(); err != nil {
-			if ctx.Err() == nil {
-				errs <- fmt.Errorf("SSE stream error: %w", err)
+		eventType = ""
+		data = ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("SSE stream error: %w", err)
+	}
+
+	return errors.New("SSE stream closed by server")
+}
+
+// backfillBlockGossip emits a synthetic block event for every block produced
+// after lastSlot, up to the current head. Empty slots are skipped. At most
+// maxBackfillSlots slots are replayed, the oldest ones are given up on.
+func (c *BeaconClient) backfillBlockGossip(ctx context.Context, lastSlot Slot, emit func(BlockEventData) error) (int, error) {
+	head, err := c.GetBlockHeader(ctx, "head")
+	if err != nil {
+		return 0, fmt.Errorf("get head header: %w", err)
+	}
+
+	headSlot := head.Header.Message.Slot
+	if headSlot <= lastSlot {
+		return 0, nil
+	}
+
+	fromSlot := lastSlot + 1
+	if headSlot-lastSlot > maxBackfillSlots {
+		fromSlot = headSlot - maxBackfillSlots + 1
+		logger.Warn("Too many missed slots, skipping the oldest ones", "lastSlot", lastSlot, "headSlot", headSlot, "skipped", fromSlot-lastSlot-1)
+	}
+
+	replayed := 0
+	for slot := fromSlot; slot <= headSlot; slot++ {
+		header := head
+		if slot != headSlot {
+			header, err = c.GetBlockHeader(ctx, fmt.Sprintf("%d", slot))
+			if errors.Is(err, errBlockNotFound) {
+				continue
+			}
+			if err != nil {
+				return replayed, fmt.Errorf("get header for slot %d: %w", slot, err)
 			}
 		}
-	}()
 
-	return events, errs
+		if err := emit(BlockEventData{Slot: slot, Block: header.Root, receivedAt: time.Now(), backfilled: true}); err != nil {
+			return replayed, err
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// GetBlockHeader fetches a block header by ID (head, root or slot).
+func (c *BeaconClient) GetBlockHeader(ctx context.Context, blockID string) (*BlockHeaderData, error) {
+	return retryValue(ctx, c.retry, "get_block_header", func() (*BlockHeaderData, error) {
+		return c.getBlockHeader(ctx, blockID)
+	})
+}
+
+// getBlockHeader makes a single attempt at fetching a block header by ID.
+func (c *BeaconClient) getBlockHeader(ctx context.Context, blockID string) (*BlockHeaderData, error) {
+	url := c.baseURL + "/eth/v1/beacon/headers/" + blockID
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %w", transportError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", errBlockNotFound, blockID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	response := new(BlockHeaderBeaconAPIResponse)
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if response.Data == nil || response.Data.Header == nil || response.Data.Header.Message == nil {
+		return nil, fmt.Errorf("empty header response for %s", blockID)
+	}
+
+	return response.Data, nil
+}
+
+// GetBlockHeadersAtSlot fetches the headers of the blocks at slot, none if the slot is empty.
+func (c *BeaconClient) GetBlockHeadersAtSlot(ctx context.Context, slot Slot) ([]*BlockHeaderData, error) {
+	response := new(BlockHeadersBeaconAPIResponse)
+	if err := c.getJSON(ctx, "get_block_headers", fmt.Sprintf("/eth/v1/beacon/headers?slot=%d", slot), response); err != nil {
+		return nil, err
+	}
+
+	if response.Data == nil {
+		return nil, errors.New("response data is nil")
+	}
+
+	return response.Data, nil
 }
 
 // GetSignedBlindedBeaconBlock fetches a signed blinded block by ID (root or slot).
+// With the SSZ block encoding, the block is requested as SSZ and decoded for
+// its fork, falling back to JSON if the beacon node or the fork does not support it.
 func (c *BeaconClient) GetSignedBlindedBeaconBlock(ctx context.Context, blockID string) (*SignedBlindedBeaconBlock, error) {
+	return retryValue(ctx, c.retry, "get_blinded_block", func() (*SignedBlindedBeaconBlock, error) {
+		return c.fetchSignedBlindedBeaconBlock(ctx, blockID)
+	})
+}
+
+// fetchSignedBlindedBeaconBlock makes a single attempt at fetching a
+// signed blinded block, as SSZ first if enabled, then as JSON.
+func (c *BeaconClient) fetchSignedBlindedBeaconBlock(ctx context.Context, blockID string) (*SignedBlindedBeaconBlock, error) {
+	if c.blockEncoding == blockEncodingSSZ {
+		// Beacon nodes without SSZ support answer JSON right away
+		block, err := c.getSignedBlindedBeaconBlock(ctx, blockID, sszMediaType+";q=1.0,"+jsonMediaType+";q=0.9")
+		if !errors.Is(err, errSSZUnavailable) {
+			return block, err
+		}
+
+		logger.Debug("SSZ block unavailable, falling back to JSON", "blockID", blockID, "error", err)
+	}
+
+	return c.getSignedBlindedBeaconBlock(ctx, blockID, jsonMediaType)
+}
+
+// getSignedBlindedBeaconBlock fetches a signed blinded block by ID with the given
+// Accept header, and decodes it according to the response content type.
+func (c *BeaconClient) getSignedBlindedBeaconBlock(ctx context.Context, blockID string, accept string) (*SignedBlindedBeaconBlock, error) {
 	url := c.baseURL + "/eth/v1/beacon/blinded_blocks/" + blockID
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", accept)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get blinded block: %w", err)
+		return nil, fmt.Errorf("failed to get blinded block: %w", transportError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("block not found: %s", blockID)
+		return nil, fmt.Errorf("%w: %s", errBlockNotFound, blockID)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
-	// Parse the JSON response to extract block_root, slot, and block_hash
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("read all: %w", err)
 	}
 
-	response := new(BlindedBlockBeaconAPIResponse)
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == sszMediaType {
+		block, err := decodeSignedBlindedBeaconBlockSSZ(resp.Header.Get(consensusVersionHeader), body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errSSZUnavailable, err)
+		}
 
-	if err := json.Unmarshal(body, &response); err != nil {
+		blocksFetched.WithLabelValues(blockEncodingSSZ).Inc()
+		return block, nil
+	}
+
+	response := new(BlindedBlockBeaconAPIResponse)
+	if err := json.Unmarshal(body, response); err != nil {
 		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
 
-	return response.Data, nil
+	if response.Data == nil {
+		return nil, errors.New("response data is nil")
+	}
+
+	// The version is required by the beacon API, the header is only a fallback
+	fork := response.Version
+	if fork == "" {
+		fork = resp.Header.Get(consensusVersionHeader)
+	}
+
+	block, err := decodeSignedBlindedBeaconBlockJSON(fork, response.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksFetched.WithLabelValues(blockEncodingJSON).Inc()
+	return block, nil
+}
+
+// checkBlindedBlockFork returns an error unless the blinded blocks of fork decode as SignedBlindedBeaconBlock.
+// Blocks of other forks lack fields or have different ones, and cannot be hashed.
+func checkBlindedBlockFork(fork string) error {
+	if !blindedBlockForks[strings.ToLower(fork)] {
+		return fmt.Errorf("unsupported fork %q", fork)
+	}
+
+	return nil
+}
+
+// decodeSignedBlindedBeaconBlockJSON decodes a JSON signed blinded block of the given fork.
+func decodeSignedBlindedBeaconBlockJSON(fork string, data []byte) (*SignedBlindedBeaconBlock, error) {
+	if err := checkBlindedBlockFork(fork); err != nil {
+		return nil, err
+	}
+
+	block := new(SignedBlindedBeaconBlock)
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, fmt.Errorf("unmarshal %s block: %w", strings.ToLower(fork), err)
+	}
+
+	return block, nil
+}
+
+// decodeSignedBlindedBeaconBlockSSZ decodes an SSZ signed blinded block of the given fork.
+func decodeSignedBlindedBeaconBlockSSZ(fork string, data []byte) (*SignedBlindedBeaconBlock, error) {
+	if err := checkBlindedBlockFork(fork); err != nil {
+		return nil, err
+	}
+	fork = strings.ToLower(fork)
+
+	block := new(SignedBlindedBeaconBlock)
+	if err := block.UnmarshalSSZ(data); err != nil {
+		return nil, fmt.Errorf("unmarshal %s block: %w", fork, err)
+	}
+
+	return block, nil
 }
 
 // SubmitSignedExecutionProof submits a signed execution proof to the beacon node pool.
+// Submitting the same proof again is harmless, so failed submissions are retried.
 func (c *BeaconClient) SubmitSignedExecutionProof(ctx context.Context, proof *SignedExecutionProof) error {
-	url := c.baseURL + "/eth/v1/prover/execution_proofs"
-
 	body, err := json.Marshal(proof)
 	if err != nil {
 		return fmt.Errorf("failed to marshal proof: %w", err)
 	}
 
+	return c.retry.do(ctx, "submit_proof", func() error {
+		return c.submitSignedExecutionProof(ctx, body)
+	})
+}
+
+// submitSignedExecutionProof makes a single attempt at submitting a JSON encoded signed execution proof.
+func (c *BeaconClient) submitSignedExecutionProof(ctx context.Context, body []byte) error {
+	url := c.baseURL + "/eth/v1/prover/execution_proofs"
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -185,13 +433,136 @@ func (c *BeaconClient) SubmitSignedExecutionProof(ctx context.Context, proof *Si
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to submit proof: %w", err)
+		return fmt.Errorf("failed to submit proof: %w", transportError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return nil
+}
+
+// GetGenesis fetches the chain genesis information.
+func (c *BeaconClient) GetGenesis(ctx context.Context) (*Genesis, error) {
+	response := new(GenesisBeaconAPIResponse)
+	if err := c.getJSON(ctx, "get_genesis", "/eth/v1/beacon/genesis", response); err != nil {
+		return nil, err
+	}
+
+	if response.Data == nil {
+		return nil, errors.New("response data is nil")
+	}
+
+	return response.Data, nil
+}
+
+// GetFork fetches the fork of the given state (e.g. head).
+func (c *BeaconClient) GetFork(ctx context.Context, stateID string) (*Fork, error) {
+	response := new(ForkBeaconAPIResponse)
+	if err := c.getJSON(ctx, "get_fork", "/eth/v1/beacon/states/"+stateID+"/fork", response); err != nil {
+		return nil, err
+	}
+
+	if response.Data == nil {
+		return nil, errors.New("response data is nil")
+	}
+
+	return response.Data, nil
+}
+
+// GetSpec fetches the chain configuration. Only scalar values are returned, as strings.
+func (c *BeaconClient) GetSpec(ctx context.Context) (map[string]string, error) {
+	response := new(SpecBeaconAPIResponse)
+	if err := c.getJSON(ctx, "get_spec", "/eth/v1/config/spec", response); err != nil {
+		return nil, err
+	}
+
+	spec := make(map[string]string, len(response.Data))
+	for key, value := range response.Data {
+		if str, ok := value.(string); ok {
+			spec[key] = str
+		}
+	}
+
+	return spec, nil
+}
+
+// CheckHealth returns an error if the beacon node is not healthy.
+// A syncing node (206) is considered healthy, as it still serves requests.
+func (c *BeaconClient) CheckHealth(ctx context.Context) error {
+	url := c.baseURL + "/eth/v1/node/health"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get node health: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("unhealthy, status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SSEConnected reports whether the block SSE stream is currently connected.
+func (c *BeaconClient) SSEConnected() bool {
+	return c.sseConnected.Load()
+}
+
+// GetValidatorIndex looks up the index of the validator with the given public key in the head state.
+func (c *BeaconClient) GetValidatorIndex(ctx context.Context, pubkey []byte) (uint64, error) {
+	response := new(ValidatorBeaconAPIResponse)
+	if err := c.getJSON(ctx, "get_validator", fmt.Sprintf("/eth/v1/beacon/states/head/validators/%#x", pubkey), response); err != nil {
+		return 0, err
+	}
+
+	if response.Data == nil {
+		return 0, errors.New("response data is nil")
+	}
+
+	return response.Data.Index, nil
+}
+
+// getJSON performs a GET request on path and decodes the JSON response into out.
+// operation names the request in retry logs and metrics.
+func (c *BeaconClient) getJSON(ctx context.Context, operation string, path string, out any) error {
+	return c.retry.do(ctx, operation, func() error {
+		return c.getJSONOnce(ctx, path, out)
+	})
+}
+
+// getJSONOnce makes a single attempt at getJSON.
+func (c *BeaconClient) getJSONOnce(ctx context.Context, path string, out any) error {
+	url := c.baseURL + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", path, transportError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode: %w", err)
 	}
 
 	return nil
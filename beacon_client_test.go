@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// signedBlockFixtureJSON returns the testdata block in file as a signed blinded block,
+// wrapped in a beacon API response of the given version unless it is empty.
+// mutate, if not nil, edits the block message first.
+func signedBlockFixtureJSON(t *testing.T, file string, version string, mutate func(message map[string]any)) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var message map[string]any
+	if err := json.Unmarshal(data, &message); err != nil {
+		t.Fatal(err)
+	}
+
+	if mutate != nil {
+		mutate(message)
+	}
+
+	var block any = map[string]any{
+		"message":   message,
+		"signature": encodeHexBytes(make([]byte, 96)),
+	}
+
+	if version != "" {
+		block = map[string]any{"version": version, "execution_optimistic": false, "finalized": true, "data": block}
+	}
+
+	return mustMarshalJSON(t, block)
+}
+
+func TestGetSignedBlindedBeaconBlockJSON(t *testing.T) {
+	fixture := electraBlockFixtures[0]
+
+	for _, tc := range []struct {
+		name    string
+		version string
+		header  string
+		mutate  func(message map[string]any)
+		wantErr error
+		wantMsg string
+	}{
+		{name: "electra", version: "electra"},
+		{name: "fulu", version: "fulu"},
+		{name: "version header only", header: "electra"},
+		{name: "deneb", version: "deneb", wantMsg: `unsupported fork "deneb"`},
+		{name: "no version", wantMsg: `unsupported fork ""`},
+		{name: "missing body", version: "electra", mutate: func(message map[string]any) { delete(message, "body") }, wantErr: errMissingField},
+		{name: "missing sync aggregate", version: "electra", mutate: func(message map[string]any) {
+			delete(message["body"].(map[string]any), "sync_aggregate")
+		}, wantErr: errMissingField},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := signedBlockFixtureJSON(t, fixture.file, tc.version, tc.mutate)
+			if tc.version == "" {
+				data = []byte(`{"data":` + string(data) + `}`)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/eth/v1/beacon/blinded_blocks/151016" {
+					http.NotFound(w, r)
+					return
+				}
+
+				w.Header().Set("Content-Type", jsonMediaType)
+				if tc.header != "" {
+					w.Header().Set(consensusVersionHeader, tc.header)
+				}
+				w.Write(data)
+			}))
+			defer server.Close()
+
+			client := NewBeaconClient(server.URL, newRetryPolicy(1))
+			client.blockEncoding = blockEncodingJSON
+
+			block, err := client.GetSignedBlindedBeaconBlock(context.Background(), "151016")
+			switch {
+			case tc.wantErr == nil && tc.wantMsg == "":
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				root, err := block.Message.HashTreeRoot()
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertBytes(t, "block root", root[:], fixture.blockRoot)
+
+			case err == nil:
+				t.Fatal("no error")
+
+			case tc.wantErr != nil && !errors.Is(err, tc.wantErr):
+				t.Fatalf("error: got %v, want %v", err, tc.wantErr)
+
+			case tc.wantMsg != "" && !strings.Contains(err.Error(), tc.wantMsg):
+				t.Fatalf("error: got %v, want %q", err, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSourcePoolSlotEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/headers" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", jsonMediaType)
+		switch r.URL.Query().Get("slot") {
+		case "10":
+			w.Write([]byte(`{"data":[]}`))
+		case "11":
+			w.Write([]byte(`{"data":[{"root":"0x7f52decd851222f7ff65908495c82bec5077dae8997fcbb8ca3b2289133fa080","canonical":true,"header":{"message":{"slot":"11","proposer_index":"1","parent_root":"0x","state_root":"0x","body_root":"0x"},"signature":"0x"}}]}`))
+		default:
+			http.Error(w, `{"code":500,"message":"internal error"}`, http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	pool, err := NewSourcePool([]*BeaconClient{NewBeaconClient(server.URL, newRetryPolicy(1))}, 0, blockEncodingJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		slot      Slot
+		wantEmpty bool
+		wantErr   bool
+	}{
+		{slot: 10, wantEmpty: true},
+		{slot: 11},
+		{slot: 12, wantErr: true},
+	} {
+		empty, err := pool.slotEmpty(context.Background(), tc.slot)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("slot %d: got error %v, want error %t", tc.slot, err, tc.wantErr)
+		}
+
+		if empty != tc.wantEmpty {
+			t.Errorf("slot %d: got empty %t, want %t", tc.slot, empty, tc.wantEmpty)
+		}
+	}
+}
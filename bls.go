@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// blsSignatureDST is the hash-to-curve domain separation tag of the Ethereum
+// proof-of-possession BLS signature scheme.
+var blsSignatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// blsSecretKey is a BLS12-381 secret key, signing with public keys in G1 and
+// signatures in G2 as the consensus layer does.
+type blsSecretKey struct {
+	scalar *big.Int
+}
+
+// newBLSSecretKey parses a 32-byte big-endian BLS secret key.
+func newBLSSecretKey(b []byte) (*blsSecretKey, error) {
+	if len(b) != fr.Bytes {
+		return nil, fmt.Errorf("invalid secret key length: got %d, want %d", len(b), fr.Bytes)
+	}
+
+	scalar := new(big.Int).SetBytes(b)
+	if scalar.Sign() == 0 {
+		return nil, errors.New("secret key is zero")
+	}
+
+	if scalar.Cmp(fr.Modulus()) >= 0 {
+		return nil, errors.New("secret key is not in the scalar field")
+	}
+
+	return &blsSecretKey{scalar: scalar}, nil
+}
+
+// PublicKey returns the compressed 48-byte public key.
+func (k *blsSecretKey) PublicKey() []byte {
+	var pubkey bls12381.G1Affine
+	pubkey.ScalarMultiplicationBase(k.scalar)
+
+	compressed := pubkey.Bytes()
+	return compressed[:]
+}
+
+// Sign returns the compressed 96-byte signature of msg.
+func (k *blsSecretKey) Sign(msg []byte) ([]byte, error) {
+	point, err := bls12381.HashToG2(msg, blsSignatureDST)
+	if err != nil {
+		return nil, fmt.Errorf("hash to G2: %w", err)
+	}
+
+	var signature bls12381.G2Affine
+	signature.ScalarMultiplication(&point, k.scalar)
+
+	compressed := signature.Bytes()
+	return compressed[:], nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// TestBLSSign checks signatures against the consensus-spec-tests bls/sign vectors.
+func TestBLSSign(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		secretKey     string
+		message       string
+		wantPubkey    string
+		wantSignature string
+	}{
+		{
+			name:          "message 0x56",
+			secretKey:     "0x263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3",
+			message:       "0x5656565656565656565656565656565656565656565656565656565656565656",
+			wantPubkey:    "0xa491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a",
+			wantSignature: "0x882730e5d03f6b42c3abc26d3372625034e1d871b65a8a6b900a56dae22da98abbe1b68f85e49fe7652a55ec3d0591c20767677e33e5cbb1207315c41a9ac03be39c2e7668edc043d6cb1d9fd93033caa8a1c5b0e84bedaeb6c64972503a43eb",
+		},
+		{
+			name:          "zero message",
+			secretKey:     "0x47b8192d77bf871b62e87859d653922725724a5c031afeabc60bcef5ff665138",
+			message:       "0x0000000000000000000000000000000000000000000000000000000000000000",
+			wantPubkey:    "0xb301803f8b5ac4a1133581fc676dfedc60d891dd5fa99028805e5ea5b08d3491af75d0707adab3b70c6a6a580217bf81",
+			wantSignature: "0xb23c46be3a001c63ca711f87a005c200cc550b9429d5f4eb38d74322144f1b63926da3388979e5321012fb1a0526bcd100b5ef5fe72628ce4cd5e904aeaa3279527843fae5ca9ca675f4f51ed8f83bbf7155da9ecc9663100a885d5dc6df96d9",
+		},
+		{
+			name:          "message 0xab",
+			secretKey:     "0x328388aff0d4a5b7dc9205abd374e7e98f3cd9f3418edb4eafda5fb16473d216",
+			message:       "0xabababababababababababababababababababababababababababababababab",
+			wantPubkey:    "0xb53d21a4cfd562c469cc81514d4ce5a6b577d8403d32a394dc265dd190b47fa9f829fdd7963afdf972e5e77854051f6f",
+			wantSignature: "0xae82747ddeefe4fd64cf9cedb9b04ae3e8a43420cd255e3c7cd06a8d88b7c7f8638543719981c5d16fa3527c468c25f0026704a6951bde891360c7e8d12ddee0559004ccdbe6046b55bae1b257ee97f7cdb955773d7cf29adf3ccbb9975e4eb9",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := newBLSSecretKey(mustDecodeHex(tc.secretKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message := mustDecodeHex(tc.message)
+			signature, err := key.Sign(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertBytes(t, "pubkey", key.PublicKey(), tc.wantPubkey)
+			assertBytes(t, "signature", signature, tc.wantSignature)
+
+			if !verifyBLSSignature(t, key.PublicKey(), message, signature) {
+				t.Error("signature does not verify")
+			}
+
+			otherMessage := append(bytes.Clone(message[:31]), message[31]^0x01)
+			if verifyBLSSignature(t, key.PublicKey(), otherMessage, signature) {
+				t.Error("signature verifies over another message")
+			}
+		})
+	}
+}
+
+func TestNewBLSSecretKeyErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		secretKey []byte
+	}{
+		{name: "short", secretKey: bytes.Repeat([]byte{0x11}, 31)},
+		{name: "zero", secretKey: make([]byte, 32)},
+		{name: "not in the scalar field", secretKey: bytes.Repeat([]byte{0xff}, 32)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newBLSSecretKey(tc.secretKey); err == nil {
+				t.Fatal("no error")
+			}
+		})
+	}
+}
+
+// verifyBLSSignature checks e(pubkey, H(message)) == e(G1, signature).
+func verifyBLSSignature(t *testing.T, pubkey, message, signature []byte) bool {
+	t.Helper()
+
+	var publicKey bls12381.G1Affine
+	if _, err := publicKey.SetBytes(pubkey); err != nil {
+		t.Fatal(err)
+	}
+
+	var sig bls12381.G2Affine
+	if _, err := sig.SetBytes(signature); err != nil {
+		t.Fatal(err)
+	}
+
+	point, err := bls12381.HashToG2(message, blsSignatureDST)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, generator, _ := bls12381.Generators()
+
+	var negatedGenerator bls12381.G1Affine
+	negatedGenerator.Neg(&generator)
+
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{publicKey, negatedGenerator}, []bls12381.G2Affine{point, sig})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ok
+}
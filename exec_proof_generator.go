@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	execProofGeneratorName = "exec"
+
+	// Placeholders substituted in the command arguments.
+	execProofTypePlaceholder = "{proof_type}"
+	execInputPlaceholder     = "{input}"
+
+	// execWaitDelay bounds how long a killed command may keep its output pipes open.
+	execWaitDelay = time.Second
+
+	// maxExecStderrLog bounds how much of the command stderr ends up in the logs.
+	maxExecStderrLog = 4096
+)
+
+var (
+	// errExecTimeout is the cause of a command killed after the generator timeout.
+	errExecTimeout = errors.New("proof command timeout")
+
+	// errProofDataTooLarge is returned once a command writes more than maxProofSize bytes.
+	errProofDataTooLarge = fmt.Errorf("proof data exceeds %d bytes", maxProofSize)
+)
+
+// execProofGenerator runs an external command for each proof.
+// The SSZ-encoded NewPayloadRequestHeader is written to the command stdin, or
+// to a temporary file whose path replaces {input} if the arguments contain it.
+// Whatever the command writes to stdout becomes the proof data, up to maxProofSize bytes.
+type execProofGenerator struct {
+	args    []string
+	timeout time.Duration
+	sem     chan struct{}
+}
+
+// newExecProofGenerator creates an external-process proof generator.
+// At most concurrency commands run at the same time for this generator.
+func newExecProofGenerator(command string, timeout time.Duration, concurrency int) (*execProofGenerator, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("exec proof command is empty")
+	}
+
+	if concurrency <= 0 {
+		return nil, fmt.Errorf("exec proof concurrency must be positive, got %d", concurrency)
+	}
+
+	return &execProofGenerator{
+		args:    args,
+		timeout: timeout,
+		sem:     make(chan struct{}, concurrency),
+	}, nil
+}
+
+// GenerateProofData implements ProofGenerator.
+func (g *execProofGenerator) GenerateProofData(ctx context.Context, req *ProofRequest) ([]byte, error) {
+	input, err := req.NewPayloadRequestHeader.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("marshal new payload request header: %w", err)
+	}
+
+	select {
+	case g.sem <- struct{}{}:
+		defer func() { <-g.sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, g.timeout, errExecTimeout)
+		defer cancel()
+	}
+
+	args, inputPath, err := g.prepareArgs(req.ProofType, input)
+	if err != nil {
+		return nil, err
+	}
+	if inputPath != "" {
+		defer os.Remove(inputPath)
+	}
+
+	// A command writing past the limit gets a broken pipe
+	stdout := &limitedBuffer{limit: maxProofSize}
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = execWaitDelay
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("PROOF_TYPE=%d", req.ProofType),
+		fmt.Sprintf("SLOT=%d", req.Block.Message.Slot),
+		fmt.Sprintf("BLOCK_HASH=%#x", req.Block.Message.Body.ExecutionPayloadHeader.BlockHash),
+		fmt.Sprintf("NEW_PAYLOAD_REQUEST_ROOT=%#x", req.NewPayloadRequestRoot),
+	)
+	if inputPath == "" {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+
+	start := time.Now()
+	err = cmd.Run()
+	elapsed := time.Since(start)
+
+	switch {
+	case stdout.exceeded:
+		err = errProofDataTooLarge
+	case err == nil:
+	case errors.Is(context.Cause(ctx), errExecTimeout):
+		err = fmt.Errorf("timed out after %s", g.timeout)
+	case ctx.Err() != nil:
+		// The caller gave up first, for example at the block deadline
+		err = fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+
+	if err != nil {
+		logger.Error("Proof command failed",
+			"command", args[0],
+			"proofType", req.ProofType,
+			"slot", req.Block.Message.Slot,
+			"elapsed", elapsed,
+			"error", err,
+			"stderr", tail(stderr.String(), maxExecStderrLog),
+		)
+
+		return nil, fmt.Errorf("run %s: %w", args[0], err)
+	}
+
+	if stderr.Len() > 0 {
+		logger.Info("Proof command stderr",
+			"command", args[0],
+			"proofType", req.ProofType,
+			"slot", req.Block.Message.Slot,
+			"stderr", tail(stderr.String(), maxExecStderrLog),
+		)
+	}
+
+	if stdout.buf.Len() == 0 {
+		return nil, fmt.Errorf("%s produced no proof data", args[0])
+	}
+
+	return stdout.buf.Bytes(), nil
+}
+
+// prepareArgs substitutes the placeholders in the command arguments.
+// If {input} is used, the input is written to a temporary file whose path is returned.
+func (g *execProofGenerator) prepareArgs(proofType ProofType, input []byte) ([]string, string, error) {
+	var inputPath string
+
+	args := make([]string, len(g.args))
+	for i, arg := range g.args {
+		if strings.Contains(arg, execInputPlaceholder) && inputPath == "" {
+			file, err := os.CreateTemp("", "new-payload-request-header-*.ssz")
+			if err != nil {
+				return nil, "", fmt.Errorf("create input file: %w", err)
+			}
+
+			inputPath = file.Name()
+
+			_, err = file.Write(input)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(inputPath)
+				return nil, "", fmt.Errorf("write input file: %w", err)
+			}
+		}
+
+		arg = strings.ReplaceAll(arg, execInputPlaceholder, inputPath)
+		arg = strings.ReplaceAll(arg, execProofTypePlaceholder, fmt.Sprintf("%d", proofType))
+		args[i] = arg
+	}
+
+	return args, inputPath, nil
+}
+
+// limitedBuffer is a buffer refusing writes that would grow it past limit bytes.
+// The buffer is not embedded, so that io.Copy cannot bypass Write with ReadFrom.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+// Write implements io.Writer.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.buf.Len()+len(p) > b.limit {
+		b.exceeded = true
+		return 0, errProofDataTooLarge
+	}
+
+	return b.buf.Write(p)
+}
+
+// tail returns at most the last n bytes of s, without surrounding whitespace.
+func tail(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		// Move the cut forward to a rune boundary so no character is split
+		start := len(s) - n
+		for start < len(s) && !utf8.RuneStart(s[start]) {
+			start++
+		}
+
+		s = "..." + s[start:]
+	}
+
+	return s
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testProofRequest returns the request of proof type 3 for the first testdata block.
+func testProofRequest(t *testing.T) *ProofRequest {
+	t.Helper()
+
+	block := readBlockFixture(t, electraBlockFixtures[0].file)
+
+	header, root, err := buildNewPayloadRequestHeader(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ProofRequest{
+		Block:                   &SignedBlindedBeaconBlock{Message: block},
+		ProofType:               3,
+		NewPayloadRequestHeader: header,
+		NewPayloadRequestRoot:   root,
+	}
+}
+
+// writeScript writes a shell script to a temporary directory and returns its path.
+func writeScript(t *testing.T, script string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "prover.sh")
+	if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestExecProofGenerator(t *testing.T) {
+	req := testProofRequest(t)
+
+	input, err := req.NewPayloadRequestHeader.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		script string
+		args   string
+		want   []byte
+	}{
+		{name: "stdin", script: "cat", want: input},
+		{name: "input file", script: `cat "$1"`, args: "{input}", want: input},
+		{
+			name:   "placeholders and environment",
+			script: `printf '%s %s %s %s %s' "$1" "$PROOF_TYPE" "$SLOT" "$BLOCK_HASH" "$NEW_PAYLOAD_REQUEST_ROOT"`,
+			args:   "type={proof_type}",
+			want:   fmt.Appendf(nil, "type=3 3 %d %#x %#x", req.Block.Message.Slot, req.Block.Message.Body.ExecutionPayloadHeader.BlockHash, req.NewPayloadRequestRoot),
+		},
+		{name: "stderr", script: "echo progress >&2; printf proof", want: []byte("proof")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			generator, err := newExecProofGenerator("sh "+writeScript(t, tc.script)+" "+tc.args, 10*time.Second, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proofData, err := generator.GenerateProofData(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(proofData, tc.want) {
+				t.Errorf("proof data: got %q, want %q", proofData, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecProofGeneratorInputFileRemoved(t *testing.T) {
+	pathFile := filepath.Join(t.TempDir(), "input-path")
+
+	generator, err := newExecProofGenerator("sh "+writeScript(t, `printf %s "$1" > "$2"; printf proof`)+" {input} "+pathFile, 10*time.Second, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := generator.GenerateProofData(context.Background(), testProofRequest(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	inputPath, err := os.ReadFile(pathFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(string(inputPath)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("input file %s not removed: %v", inputPath, err)
+	}
+}
+
+func TestExecProofGeneratorErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+		wantErr string
+		wantIs  error
+	}{
+		{name: "non-zero exit", script: "echo failed >&2; exit 3", wantErr: "exit status 3"},
+		{name: "empty output", script: "exit 0", wantErr: "produced no proof data"},
+		{name: "oversized output", script: fmt.Sprintf("head -c %d /dev/zero", maxProofSize+1), wantIs: errProofDataTooLarge},
+		{name: "endless output", script: "exec cat /dev/zero", wantIs: errProofDataTooLarge},
+		{name: "timeout", script: "exec sleep 10", timeout: 100 * time.Millisecond, wantErr: "timed out after 100ms"},
+		{
+			name:   "caller deadline",
+			script: "exec sleep 10",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+			wantIs: context.DeadlineExceeded,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout := tc.timeout
+			if timeout == 0 {
+				timeout = 10 * time.Second
+			}
+
+			generator, err := newExecProofGenerator("sh "+writeScript(t, tc.script), timeout, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.ctx != nil {
+				ctx, cancel = tc.ctx()
+			}
+			defer cancel()
+
+			_, err = generator.GenerateProofData(ctx, testProofRequest(t))
+			if err == nil {
+				t.Fatal("no error")
+			}
+
+			if tc.wantErr != "" && !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("error: got %v, want %q", err, tc.wantErr)
+			}
+
+			if tc.wantIs != nil && !errors.Is(err, tc.wantIs) {
+				t.Errorf("error: got %v, want %v", err, tc.wantIs)
+			}
+
+			if tc.wantIs == context.DeadlineExceeded && strings.Contains(err.Error(), "timed out after") {
+				t.Errorf("error: got %v, blaming the generator timeout", err)
+			}
+		})
+	}
+}
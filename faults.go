@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	mathrand "math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Faults that can be injected into proofs.
+const (
+	faultWrongRoot          = "wrong-root"
+	faultProofType          = "proof-type"
+	faultOversizedData      = "oversized-data"
+	faultBadSignature       = "bad-signature"
+	faultMalformedSignature = "malformed-signature"
+	faultValidatorIndex     = "validator-index"
+	faultUnknownBlock       = "unknown-block"
+)
+
+// faults lists every fault, in the order they are drawn.
+var faults = []string{
+	faultWrongRoot,
+	faultUnknownBlock,
+	faultProofType,
+	faultBadSignature,
+	faultMalformedSignature,
+	faultOversizedData,
+	faultValidatorIndex,
+}
+
+// FaultInjector corrupts proofs on purpose, to test how beacon nodes handle invalid ones.
+// Each fault is injected independently, with a probability set per proof type.
+// A nil *FaultInjector injects nothing.
+type FaultInjector struct {
+	// probabilities holds the probability of each fault, by proof type.
+	probabilities []map[string]float64
+}
+
+// newFaultInjector parses a comma-separated list of [<proof type>:]<fault>=<probability> entries.
+// Entries without a proof type apply to every proof type, entries with one override them.
+// It returns nil if spec is empty.
+func newFaultInjector(spec string, proofsPerBlock int) (*FaultInjector, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	global := make(map[string]float64)
+	overrides := make(map[ProofType]map[string]float64)
+
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		target, probabilityStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("missing probability in %q", entry)
+		}
+
+		probability, err := strconv.ParseFloat(strings.TrimSpace(probabilityStr), 64)
+		if err != nil || probability < 0 || probability > 1 {
+			return nil, fmt.Errorf("invalid probability in %q, want a number between 0 and 1", entry)
+		}
+
+		probabilities := global
+		fault := strings.TrimSpace(target)
+		if proofTypeStr, name, ok := strings.Cut(target, ":"); ok {
+			proofType, err := strconv.ParseUint(strings.TrimSpace(proofTypeStr), 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("parse proof type in %q: %w", entry, err)
+			}
+
+			if int(proofType) >= proofsPerBlock {
+				return nil, fmt.Errorf("proof type %d out of range, only %d proofs per block", proofType, proofsPerBlock)
+			}
+
+			if overrides[ProofType(proofType)] == nil {
+				overrides[ProofType(proofType)] = make(map[string]float64)
+			}
+
+			probabilities = overrides[ProofType(proofType)]
+			fault = strings.TrimSpace(name)
+		}
+
+		if !isFault(fault) {
+			return nil, fmt.Errorf("unknown fault %q (want one of %s)", fault, strings.Join(faults, ", "))
+		}
+
+		probabilities[fault] = probability
+	}
+
+	injector := &FaultInjector{
+		probabilities: make([]map[string]float64, proofsPerBlock),
+	}
+
+	for proofType := range ProofType(proofsPerBlock) {
+		probabilities := make(map[string]float64, len(faults))
+		for fault, probability := range global {
+			probabilities[fault] = probability
+		}
+		for fault, probability := range overrides[proofType] {
+			probabilities[fault] = probability
+		}
+
+		injector.probabilities[proofType] = probabilities
+	}
+
+	return injector, nil
+}
+
+// isFault reports whether name is a known fault.
+func isFault(name string) bool {
+	for _, fault := range faults {
+		if fault == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// draw reports whether fault should be injected into a proof of the given type.
+func (f *FaultInjector) draw(proofType ProofType, fault string) bool {
+	probability := f.probabilities[proofType][fault]
+	return probability > 0 && mathrand.Float64() < probability
+}
+
+// injectBeforeSigning corrupts proof before it is signed, so that the signature
+// is valid for the corrupted proof. header is the NewPayloadRequestHeader proof commits to.
+// It returns the injected faults.
+func (f *FaultInjector) injectBeforeSigning(slot Slot, proof *ExecutionProof, header *NewPayloadRequestHeader) ([]string, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	proofType := proof.ProofType
+	var injected []string
+
+	if f.draw(proofType, faultWrongRoot) {
+		proof.PublicInput = &PublicInput{NewPayloadRequestRoot: randomBytes(32)}
+		f.record(slot, proofType, faultWrongRoot)
+		injected = append(injected, faultWrongRoot)
+	}
+
+	if f.draw(proofType, faultUnknownBlock) {
+		// Commit to the same payload, with a block hash no node has seen
+		payloadHeader := *header.ExecutionPayloadHeader
+		payloadHeader.BlockHash = randomBytes(32)
+
+		unknownHeader := *header
+		unknownHeader.ExecutionPayloadHeader = &payloadHeader
+
+		root, err := unknownHeader.HashTreeRoot()
+		if err != nil {
+			return injected, fmt.Errorf("unknown block new payload request root: %w", err)
+		}
+
+		proof.PublicInput = &PublicInput{NewPayloadRequestRoot: root[:]}
+		f.record(slot, proofType, faultUnknownBlock)
+		injected = append(injected, faultUnknownBlock)
+	}
+
+	if f.draw(proofType, faultProofType) {
+		proof.ProofType = ProofType(proofTypeCount + mathrand.IntN(256-proofTypeCount))
+		f.record(slot, proofType, faultProofType)
+		injected = append(injected, faultProofType)
+	}
+
+	return injected, nil
+}
+
+// injectAfterSigning corrupts a signed proof of the given type, invalidating
+// its signature or its size. signer signs the bad signatures.
+// It returns the injected faults.
+func (f *FaultInjector) injectAfterSigning(ctx context.Context, slot Slot, proofType ProofType, signed *SignedExecutionProof, signer Signer) ([]string, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var injected []string
+
+	if f.draw(proofType, faultBadSignature) {
+		// A valid signature, over a proof with other data
+		message := *signed.Message
+		message.ProofData = slices.Clone(message.ProofData)
+		if len(message.ProofData) == 0 {
+			message.ProofData = []byte{0}
+		} else {
+			message.ProofData[len(message.ProofData)-1] ^= 0xff
+		}
+
+		other, err := signer.SignExecutionProof(ctx, &message)
+		if err != nil {
+			return injected, fmt.Errorf("sign bad signature: %w", err)
+		}
+
+		// Keep the validator index of the signature, in case the signer rotates keys
+		signed.Signature = other.Signature
+		signed.ValidatorIndex = other.ValidatorIndex
+
+		f.record(slot, proofType, faultBadSignature)
+		injected = append(injected, faultBadSignature)
+	}
+
+	if f.draw(proofType, faultMalformedSignature) {
+		signature := make([]byte, len(signed.Signature))
+		copy(signature, signed.Signature)
+		if len(signature) > 0 {
+			signature[len(signature)-1] ^= 0xff
+		}
+		signed.Signature = signature
+
+		f.record(slot, proofType, faultMalformedSignature)
+		injected = append(injected, faultMalformedSignature)
+	}
+
+	if f.draw(proofType, faultOversizedData) {
+		// The proof data is too large to be hashed, so this can only happen once signed
+		message := *signed.Message
+		padding := randomBytes(max(maxProofSize+1-len(message.ProofData), 1))
+		message.ProofData = append(padding, message.ProofData...)
+		signed.Message = &message
+
+		f.record(slot, proofType, faultOversizedData)
+		injected = append(injected, faultOversizedData)
+	}
+
+	if f.draw(proofType, faultValidatorIndex) {
+		signed.ValidatorIndex += 1 + mathrand.Uint64N(1<<20)
+		f.record(slot, proofType, faultValidatorIndex)
+		injected = append(injected, faultValidatorIndex)
+	}
+
+	return injected, nil
+}
+
+// record logs and counts an injected fault.
+func (f *FaultInjector) record(slot Slot, proofType ProofType, fault string) {
+	faultsInjected.WithLabelValues(proofTypeLabel(proofType), fault).Inc()
+	logger.Warn("Injected fault", "fault", fault, "slot", slot, "proofType", proofType)
+}
+
+// randomBytes returns n random bytes.
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// rootSigner signs the hash tree root of proofs with a fixed key, as validator 42.
+type rootSigner struct {
+	key *blsSecretKey
+}
+
+func newRootSigner(t *testing.T) *rootSigner {
+	t.Helper()
+
+	key, err := newBLSSecretKey(bytes.Repeat([]byte{0x11}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &rootSigner{key: key}
+}
+
+func (s *rootSigner) sign(t *testing.T, proof *ExecutionProof) []byte {
+	t.Helper()
+
+	signed, err := s.SignExecutionProof(context.Background(), proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed.Signature
+}
+
+// SignExecutionProof implements Signer.
+func (s *rootSigner) SignExecutionProof(_ context.Context, proof *ExecutionProof) (*SignedExecutionProof, error) {
+	root, err := proof.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.key.Sign(root[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedExecutionProof{Message: proof, ValidatorIndex: 42, Signature: signature}, nil
+}
+
+func TestInjectSignatureFaults(t *testing.T) {
+	signer := newRootSigner(t)
+	proof := testExecutionProof()
+	signature := signer.sign(t, proof)
+
+	// The bad signature is the validator's over the proof with other data
+	otherProof := testExecutionProof()
+	otherProof.ProofData = []byte{0x01, 0x02, 0xfc}
+	otherSignature := signer.sign(t, otherProof)
+
+	for _, tc := range []struct {
+		fault         string
+		wantSignature []byte
+		wantValid     bool
+	}{
+		{fault: faultBadSignature, wantSignature: otherSignature, wantValid: true},
+		{fault: faultMalformedSignature, wantSignature: append(bytes.Clone(signature[:95]), signature[95]^0xff)},
+	} {
+		t.Run(tc.fault, func(t *testing.T) {
+			injector, err := newFaultInjector(tc.fault+"=1", 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed := &SignedExecutionProof{Message: testExecutionProof(), ValidatorIndex: 42, Signature: bytes.Clone(signature)}
+
+			injected, err := injector.injectAfterSigning(context.Background(), 1, 1, signed, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(injected, []string{tc.fault}) {
+				t.Fatalf("injected faults: got %v, want [%s]", injected, tc.fault)
+			}
+
+			// Only the signature is corrupted
+			assertJSONEqual(t, mustMarshalJSON(t, signed.Message), mustMarshalJSON(t, proof))
+
+			if signed.ValidatorIndex != 42 {
+				t.Errorf("validator index: got %d, want 42", signed.ValidatorIndex)
+			}
+
+			if !bytes.Equal(signed.Signature, tc.wantSignature) {
+				t.Errorf("signature: got %#x, want %#x", signed.Signature, tc.wantSignature)
+			}
+
+			// A bad signature must still decode to a point of the signature subgroup
+			if tc.wantValid {
+				var point bls12381.G2Affine
+				if _, err := point.SetBytes(signed.Signature); err != nil {
+					t.Errorf("signature is not a G2 point: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFaultyProofRejected(t *testing.T) {
+	fixture := electraBlockFixtures[0]
+
+	blocks := t.TempDir()
+	if err := os.WriteFile(filepath.Join(blocks, fixture.file), signedBlockFixtureJSON(t, fixture.file, "electra", nil), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := OpenFileSource(blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &recordingTarget{err: errors.New("invalid execution proof")}
+	submitter, err := NewSubmitter([]proofTarget{target}, submissionPolicyAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generators, err := newProofGenerators(dummyProofGeneratorName, 1, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	injector, err := newFaultInjector(faultWrongRoot+"=1", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rejected := faultySubmissions.WithLabelValues("0", faultWrongRoot, faultResultRejected)
+	accepted := faultySubmissions.WithLabelValues("0", faultWrongRoot, faultResultAccepted)
+	submitFailures := failures.WithLabelValues(stageSubmit, "0")
+	rejectedBefore, acceptedBefore, failuresBefore := testutil.ToFloat64(rejected), testutil.ToFloat64(accepted), testutil.ToFloat64(submitFailures)
+
+	prover := NewProver(source, submitter, newRootSigner(t), generators, nil, injector, nil, 0, 0, 1)
+
+	// The target rejecting the faulty proof is the expected outcome, not a failure of the block
+	if _, err := prover.handleSlot(context.Background(), fixture.slot); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(target.submitted) != 1 {
+		t.Fatalf("submissions: got %d, want 1", len(target.submitted))
+	}
+
+	if got := testutil.ToFloat64(rejected) - rejectedBefore; got != 1 {
+		t.Errorf("rejected faulty submissions: got %v, want 1", got)
+	}
+
+	if got := testutil.ToFloat64(accepted) - acceptedBefore; got != 0 {
+		t.Errorf("accepted faulty submissions: got %v, want 0", got)
+	}
+
+	if got := testutil.ToFloat64(submitFailures) - failuresBefore; got != 0 {
+		t.Errorf("submit failures: got %v, want 0", got)
+	}
+}
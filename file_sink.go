@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileSink writes signed proofs to files rather than submitting them to a beacon node,
+// for offline runs: one JSON file per proof in a directory, or one line per proof in
+// a JSON lines file. Proofs are encoded as submitted to beacon nodes.
+type FileSink struct {
+	path string
+	dir  bool
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileSink opens the output at path, a directory if it exists as one or ends
+// with a slash, else a JSON lines file appended to.
+func OpenFileSink(path string) (*FileSink, error) {
+	dir := strings.HasSuffix(path, "/")
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		dir = true
+	}
+
+	sink := &FileSink{
+		path: filepath.Clean(path),
+		dir:  dir,
+	}
+
+	if dir {
+		if err := os.MkdirAll(sink.path, 0o755); err != nil {
+			return nil, err
+		}
+
+		return sink, nil
+	}
+
+	file, err := os.OpenFile(sink.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	sink.file = file
+
+	return sink, nil
+}
+
+// URL returns the path proofs are written to.
+func (s *FileSink) URL() string {
+	return s.path
+}
+
+// SubmitSignedExecutionProof writes proof. In a directory, it is named after its
+// new payload request root and proof type, and a proof written again replaces the previous one.
+func (s *FileSink) SubmitSignedExecutionProof(_ context.Context, proof *SignedExecutionProof) error {
+	if !s.dir {
+		data, err := json.Marshal(proof)
+		if err != nil {
+			return fmt.Errorf("marshal proof: %w", err)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, err := s.file.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("write proof: %w", err)
+		}
+
+		return nil
+	}
+
+	data, err := json.MarshalIndent(proof, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal proof: %w", err)
+	}
+
+	var root []byte
+	if proof.Message.PublicInput != nil {
+		root = proof.Message.PublicInput.NewPayloadRequestRoot
+	}
+	name := fmt.Sprintf("%#x-%d.json", root, proof.Message.ProofType)
+
+	// Write to a temporary file first, so that a proof file is never partially written
+	path := filepath.Join(s.path, name)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write proof: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("write proof: %w", err)
+	}
+
+	return nil
+}
+
+// CheckHealth always succeeds, files being written on demand.
+func (s *FileSink) CheckHealth(context.Context) error {
+	return nil
+}
+
+// Close closes the JSON lines file, if any.
+func (s *FileSink) Close() error {
+	if s == nil || s.file == nil {
+		return nil
+	}
+
+	return s.file.Close()
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxFileSourceLineSize bounds the size of a block in a JSON lines file of blocks.
+const maxFileSourceLineSize = 64 << 20
+
+// errOffline is returned for chain information not available from files.
+var errOffline = errors.New("not available offline")
+
+// fileBlock is a block read from a file, with its root.
+type fileBlock struct {
+	root  Root
+	block *SignedBlindedBeaconBlock
+}
+
+// FileSource sources blocks from files rather than beacon nodes, for offline runs:
+// a directory of signed blinded block files, in JSON (.json) or SSZ (.ssz), or a
+// JSON lines file with one block per line. JSON blocks are either bare or wrapped in a
+// beacon API response. Block events announce every block once, in slot order.
+type FileSource struct {
+	path   string
+	blocks []*fileBlock
+	byRoot map[Root]*fileBlock
+	bySlot map[Slot]*fileBlock
+}
+
+// OpenFileSource reads the blocks of path, a directory of block files or a JSON lines file.
+func OpenFileSource(path string) (*FileSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*SignedBlindedBeaconBlock
+	if info.IsDir() {
+		blocks, err = readBlockDir(path)
+	} else {
+		blocks, err = readBlockLines(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	source := &FileSource{
+		path:   path,
+		byRoot: make(map[Root]*fileBlock, len(blocks)),
+		bySlot: make(map[Slot]*fileBlock, len(blocks)),
+	}
+
+	for _, block := range blocks {
+		if block.Message == nil {
+			return nil, errors.New("block has no message")
+		}
+
+		root, err := block.Message.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("block root of slot %d: %w", block.Message.Slot, err)
+		}
+
+		if _, ok := source.byRoot[root]; ok {
+			continue
+		}
+
+		if other, ok := source.bySlot[block.Message.Slot]; ok {
+			return nil, fmt.Errorf("blocks %#x and %#x both at slot %d", other.root, root, block.Message.Slot)
+		}
+
+		fb := &fileBlock{root: root, block: block}
+		source.blocks = append(source.blocks, fb)
+		source.byRoot[root] = fb
+		source.bySlot[block.Message.Slot] = fb
+	}
+
+	if len(source.blocks) == 0 {
+		return nil, fmt.Errorf("no block in %s", path)
+	}
+
+	slices.SortFunc(source.blocks, func(a, b *fileBlock) int {
+		return cmp.Compare(a.block.Message.Slot, b.block.Message.Slot)
+	})
+
+	return source, nil
+}
+
+// readBlockDir reads the .json and .ssz block files of dir, skipping other files.
+func readBlockDir(dir string) ([]*SignedBlindedBeaconBlock, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*SignedBlindedBeaconBlock
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".ssz") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var block *SignedBlindedBeaconBlock
+		if ext == ".ssz" {
+			block = new(SignedBlindedBeaconBlock)
+			err = block.UnmarshalSSZ(data)
+		} else {
+			block, err = decodeBlockJSON(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", path, err)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// readBlockLines reads the JSON lines file of blocks at path, skipping empty lines.
+func readBlockLines(path string) ([]*SignedBlindedBeaconBlock, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxFileSourceLineSize)
+
+	var blocks []*SignedBlindedBeaconBlock
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		block, err := decodeBlockJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s line %d: %w", path, line, err)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// decodeBlockJSON decodes a signed blinded block, bare or wrapped in a beacon API response.
+// The fork of wrapped blocks is checked, bare blocks are assumed to be of a supported fork.
+func decodeBlockJSON(data []byte) (*SignedBlindedBeaconBlock, error) {
+	var response BlindedBlockBeaconAPIResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Version != "" {
+		return decodeSignedBlindedBeaconBlockJSON(response.Version, response.Data)
+	}
+
+	if response.Data != nil {
+		data = response.Data
+	}
+
+	block := new(SignedBlindedBeaconBlock)
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// URLs returns the path blocks are read from.
+func (s *FileSource) URLs() []string {
+	return []string{s.path}
+}
+
+// GetSignedBlindedBeaconBlock returns the block with the given ID: head, a slot or a 0x-prefixed block root.
+func (s *FileSource) GetSignedBlindedBeaconBlock(_ context.Context, blockID string) (*SignedBlindedBeaconBlock, error) {
+	var (
+		fb *fileBlock
+		ok bool
+	)
+
+	switch {
+	case blockID == "head":
+		fb, ok = s.blocks[len(s.blocks)-1], true
+
+	case strings.HasPrefix(blockID, "0x"):
+		root, err := decodeHexBytes(blockID)
+		if err != nil || len(root) != len(Root{}) {
+			return nil, fmt.Errorf("invalid block root %q", blockID)
+		}
+		fb, ok = s.byRoot[Root(root)]
+
+	default:
+		slot, err := strconv.ParseUint(blockID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported block ID %q offline, want head, a slot or a block root", blockID)
+		}
+		fb, ok = s.bySlot[Slot(slot)]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errBlockNotFound, blockID)
+	}
+
+	return fb.block, nil
+}
+
+// subscribeToBlockGossip announces every block in slot order, then closes the channel.
+func (s *FileSource) subscribeToBlockGossip(ctx context.Context) <-chan BlockEventData {
+	events := make(chan BlockEventData)
+
+	go func() {
+		defer close(events)
+
+		for _, fb := range s.blocks {
+			event := BlockEventData{
+				Slot:       fb.block.Message.Slot,
+				Block:      fb.root,
+				receivedAt: time.Now(),
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// headSlot returns the slot of the last block.
+func (s *FileSource) headSlot(context.Context) (Slot, error) {
+	return s.blocks[len(s.blocks)-1].block.Message.Slot, nil
+}
+
+// slotEmpty reports whether no file holds a block at slot, the files being the whole chain.
+func (s *FileSource) slotEmpty(_ context.Context, slot Slot) (bool, error) {
+	_, ok := s.bySlot[slot]
+	return !ok, nil
+}
+
+// GetGenesis fails, as files do not tell the genesis of the chain.
+func (s *FileSource) GetGenesis(context.Context) (*Genesis, error) {
+	return nil, fmt.Errorf("genesis: %w", errOffline)
+}
+
+// GetSpec fails, as files do not tell the chain configuration.
+func (s *FileSource) GetSpec(context.Context) (map[string]string, error) {
+	return nil, fmt.Errorf("spec: %w", errOffline)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenFileSource(t *testing.T) {
+	dir := t.TempDir()
+	for i, fixture := range electraBlockFixtures {
+		// Both bare and wrapped blocks are read
+		version := ""
+		if i%2 == 0 {
+			version = "electra"
+		}
+
+		path := filepath.Join(dir, fixture.file)
+		if err := os.WriteFile(path, signedBlockFixtureJSON(t, fixture.file, version, nil), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	source, err := OpenFileSource(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fixture := range electraBlockFixtures {
+		block, err := source.GetSignedBlindedBeaconBlock(context.Background(), fixture.blockRoot)
+		if err != nil {
+			t.Fatalf("block %s: %v", fixture.blockRoot, err)
+		}
+
+		if block.Message.Slot != fixture.slot {
+			t.Errorf("block %s: got slot %d, want %d", fixture.blockRoot, block.Message.Slot, fixture.slot)
+		}
+	}
+
+	// Slot order: deposits, consolidations, then withdrawals
+	head, err := source.GetSignedBlindedBeaconBlock(context.Background(), "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if head.Message.Slot != 151850 {
+		t.Errorf("head: got slot %d, want 151850", head.Message.Slot)
+	}
+}
+
+func TestOpenFileSourceRejectsBlocks(t *testing.T) {
+	withoutExecutionRequests := func(message map[string]any) {
+		delete(message["body"].(map[string]any), "execution_requests")
+	}
+
+	for _, tc := range []struct {
+		name    string
+		version string
+		mutate  func(message map[string]any)
+		wantErr error
+		wantMsg string
+	}{
+		{name: "incomplete bare block", mutate: withoutExecutionRequests, wantErr: errMissingField},
+		{name: "incomplete wrapped block", version: "electra", mutate: withoutExecutionRequests, wantErr: errMissingField},
+		{name: "unsupported fork", version: "deneb", mutate: withoutExecutionRequests, wantMsg: `unsupported fork "deneb"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			file := electraBlockFixtures[0].file
+			data := signedBlockFixtureJSON(t, file, tc.version, tc.mutate)
+
+			// As a block file, then as a JSON lines file
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, file), data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			lines := filepath.Join(t.TempDir(), "blocks.jsonl")
+			if err := os.WriteFile(lines, append(data, '\n'), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, path := range []string{dir, lines} {
+				_, err := OpenFileSource(path)
+				switch {
+				case err == nil:
+					t.Errorf("%s: no error", path)
+				case tc.wantErr != nil && !errors.Is(err, tc.wantErr):
+					t.Errorf("%s: got %v, want %v", path, err, tc.wantErr)
+				case tc.wantMsg != "" && !strings.Contains(err.Error(), tc.wantMsg):
+					t.Errorf("%s: got %v, want %q", path, err, tc.wantMsg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// healthCheckTimeout bounds each dependency check of a probe.
+	healthCheckTimeout = 3 * time.Second
+
+	// heartbeatInterval is how often the event loop reports it is alive.
+	heartbeatInterval = 5 * time.Second
+
+	// livenessTimeout is how long the event loop may go without a heartbeat before the prover is considered wedged.
+	livenessTimeout = 2 * time.Minute
+)
+
+type (
+	// healthChecker is implemented by dependencies able to report their health.
+	healthChecker interface {
+		CheckHealth(ctx context.Context) error
+	}
+
+	// checkResult is the outcome of a single liveness or readiness check.
+	checkResult struct {
+		Name   string `json:"name"`
+		OK     bool   `json:"ok"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	// healthResponse is the JSON body of /livez and /readyz.
+	healthResponse struct {
+		Status string         `json:"status"`
+		Checks []*checkResult `json:"checks"`
+	}
+)
+
+// Health tracks the prover state needed to answer liveness and readiness probes.
+type Health struct {
+	sources    *SourcePool
+	submitter  *Submitter
+	signer     Signer
+	clock      *lazySlotClock
+	maxSlotLag uint64
+	startedAt  time.Time
+
+	heartbeat         atomic.Int64
+	lastProcessedSlot atomic.Uint64
+	processed         atomic.Bool
+	shuttingDown      atomic.Bool
+}
+
+// NewHealth creates the health state of the prover.
+// Readiness requires a block to have been processed within maxSlotLag slots, 0 disables this check.
+func NewHealth(sources *SourcePool, submitter *Submitter, signer Signer, clock *lazySlotClock, maxSlotLag uint64) *Health {
+	h := &Health{
+		sources:    sources,
+		submitter:  submitter,
+		signer:     signer,
+		clock:      clock,
+		maxSlotLag: maxSlotLag,
+		startedAt:  time.Now(),
+	}
+
+	h.Heartbeat()
+	return h
+}
+
+// Heartbeat records that the event loop is making progress.
+func (h *Health) Heartbeat() {
+	h.heartbeat.Store(time.Now().UnixNano())
+}
+
+// RecordProcessed records that the proofs of a block at slot were all submitted.
+func (h *Health) RecordProcessed(slot Slot) {
+	for {
+		last := h.lastProcessedSlot.Load()
+		if h.processed.Load() && uint64(slot) <= last {
+			return
+		}
+
+		if h.lastProcessedSlot.CompareAndSwap(last, uint64(slot)) {
+			h.processed.Store(true)
+			lastProcessedSlot.Set(float64(slot))
+			return
+		}
+	}
+}
+
+// SetShuttingDown makes readiness fail, so no more traffic is routed to the prover while it drains.
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// livez runs the liveness checks.
+func (h *Health) livez(_ context.Context) []*checkResult {
+	sinceHeartbeat := time.Since(time.Unix(0, h.heartbeat.Load()))
+
+	return []*checkResult{
+		result("event_loop", sinceHeartbeat <= livenessTimeout, fmt.Sprintf("last heartbeat %s ago", sinceHeartbeat.Truncate(time.Millisecond))),
+	}
+}
+
+// readyz runs the readiness checks concurrently.
+func (h *Health) readyz(ctx context.Context) []*checkResult {
+	checks := []func(context.Context) *checkResult{
+		h.checkShutdown,
+		h.checkSSE,
+		h.checkTargets,
+		h.checkSigner,
+		h.checkRecentBlock,
+	}
+
+	results := make([]*checkResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			results[i] = check(checkCtx)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// checkShutdown checks that the prover is not shutting down.
+func (h *Health) checkShutdown(_ context.Context) *checkResult {
+	if h.shuttingDown.Load() {
+		return result("shutdown", false, "shutting down")
+	}
+
+	return result("shutdown", true, "")
+}
+
+// checkSSE checks that the SSE subscription to the active source is connected.
+func (h *Health) checkSSE(_ context.Context) *checkResult {
+	if h.sources == nil {
+		return result("source_sse", true, "reading blocks from files")
+	}
+
+	source := h.sources.Active()
+	if !source.SSEConnected() {
+		return result("source_sse", false, "not connected to "+source.baseURL)
+	}
+
+	return result("source_sse", true, "connected to "+source.baseURL)
+}
+
+// checkTargets checks that enough target beacon nodes respond to meet the submission policy.
+func (h *Health) checkTargets(ctx context.Context) *checkResult {
+	targets := h.submitter.targets
+
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := target.CheckHealth(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", target.URL(), err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := make([]string, 0, len(targets))
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+
+	healthy := len(targets) - len(failed)
+	detail := fmt.Sprintf("%d of %d healthy, policy %s", healthy, len(targets), h.submitter.policy)
+	if len(failed) > 0 {
+		detail += ": " + strings.Join(failed, "; ")
+	}
+
+	return result("target_beacon_nodes", h.submitter.satisfied(healthy), detail)
+}
+
+// checkSigner checks that the signer responds, if it has a way to tell.
+func (h *Health) checkSigner(ctx context.Context) *checkResult {
+	checker, ok := h.signer.(healthChecker)
+	if !ok {
+		return result("signer", true, "local signer")
+	}
+
+	if err := checker.CheckHealth(ctx); err != nil {
+		return result("signer", false, err.Error())
+	}
+
+	return result("signer", true, "")
+}
+
+// checkRecentBlock checks that a block was processed within the allowed number of slots.
+// Before the first block, the lag is counted from the prover start. Blocks read from
+// files have no slot clock to lag behind.
+func (h *Health) checkRecentBlock(ctx context.Context) *checkResult {
+	const name = "recent_block"
+
+	if h.maxSlotLag == 0 {
+		return result(name, true, "disabled")
+	}
+
+	if h.sources == nil {
+		return result(name, true, "reading blocks from files")
+	}
+
+	clock, err := h.clock.get(ctx)
+	if err != nil {
+		return result(name, false, fmt.Sprintf("slot clock: %v", err))
+	}
+
+	currentSlot := clock.CurrentSlot()
+
+	reference, what := clock.SlotAt(h.startedAt), "start slot"
+	if h.processed.Load() {
+		reference, what = Slot(h.lastProcessedSlot.Load()), "last processed slot"
+	}
+
+	var lag uint64
+	if currentSlot > reference {
+		lag = uint64(currentSlot - reference)
+	}
+
+	return result(name, lag <= h.maxSlotLag, fmt.Sprintf("%s %d, current slot %d, lag %d (max %d)", what, reference, currentSlot, lag, h.maxSlotLag))
+}
+
+// handler serves the outcome of the given checks as JSON, with a 503 status if any failed.
+func (h *Health) handler(checks func(context.Context) []*checkResult) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := &healthResponse{
+			Status: "ok",
+			Checks: checks(r.Context()),
+		}
+
+		status := http.StatusOK
+		for _, check := range response.Checks {
+			if !check.OK {
+				response.Status = "fail"
+				status = http.StatusServiceUnavailable
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// result builds a check result.
+func result(name string, ok bool, detail string) *checkResult {
+	return &checkResult{
+		Name:   name,
+		OK:     ok,
+		Detail: detail,
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Output formats of the inspect command.
+const (
+	inspectFormatText = "text"
+	inspectFormatJSON = "json"
+)
+
+// inspection is what the prover computes for a block, as printed by the inspect command.
+type inspection struct {
+	BlockRoot               string                  `json:"block_root"`
+	Slot                    Slot                    `json:"slot"`
+	ExecutionPayloadHeader  *ExecutionPayloadHeader `json:"execution_payload_header"`
+	VersionedHashes         []string                `json:"versioned_hashes"`
+	ExecutionRequests       *ExecutionRequests      `json:"execution_requests"`
+	NewPayloadRequestHeader string                  `json:"new_payload_request_header"`
+	NewPayloadRequestRoot   string                  `json:"new_payload_request_root"`
+	Proofs                  []*inspectedProof       `json:"proofs"`
+}
+
+// inspectedProof is the proof data a proof type gets for the inspected block.
+type inspectedProof struct {
+	ProofType string `json:"proof_type"`
+	ProofData string `json:"proof_data"`
+}
+
+// inspectMain runs the inspect command: print what the prover computes for a block.
+func inspectMain(args []string) error {
+	var cfg Config
+	var format string
+
+	fs := flag.NewFlagSet(inspectCommand, flag.ExitOnError)
+	cfg.registerSourceFlags(fs, defaultBeaconNodeURL)
+	cfg.registerProofGeneratorFlags(fs)
+	fs.StringVar(&format, "format", inspectFormatText, fmt.Sprintf("Output format: %s or %s", inspectFormatText, inspectFormatJSON))
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		logger.Error("Expected a single block ID: head, finalized, a slot or a 0x-prefixed block root", "args", fs.Args())
+		return errors.New("expected a single block ID")
+	}
+
+	if format != inspectFormatText && format != inspectFormatJSON {
+		logger.Error("Invalid output format", "format", format)
+		return fmt.Errorf("invalid output format %q", format)
+	}
+
+	if err := inspect(context.Background(), cfg, fs.Arg(0), format, os.Stdout); err != nil {
+		logger.Error("Failed to inspect block", "blockID", fs.Arg(0), "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// inspect fetches the block with the given ID from the sources of cfg, and writes
+// to w the public input the prover computes for it and the proof data of each proof type.
+func inspect(ctx context.Context, cfg Config, blockID string, format string, w io.Writer) error {
+	sources, err := newBlockSource(cfg, newRetryPolicy(cfg.RetryMaxAttempts))
+	if err != nil {
+		return fmt.Errorf("new block source: %w", err)
+	}
+
+	generators, err := newProofGenerators(cfg.ProofGenerator, cfg.ProofsPerBlock, cfg)
+	if err != nil {
+		return fmt.Errorf("new proof generators: %w", err)
+	}
+
+	block, err := sources.GetSignedBlindedBeaconBlock(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("get signed blinded beacon block: %w", err)
+	}
+
+	if block.Message == nil {
+		return errors.New("block has no message")
+	}
+
+	blockRoot, err := block.Message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("block root: %w", err)
+	}
+
+	// A block fetched by root must hash to it
+	if root, err := decodeHexBytes(blockID); err == nil && len(root) == len(blockRoot) {
+		if err := verifyBlockRoot(block, Root(root)); err != nil {
+			return err
+		}
+	}
+
+	header, root, err := buildNewPayloadRequestHeader(block.Message)
+	if err != nil {
+		return err
+	}
+
+	headerSSZ, err := header.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("encode new payload request header: %w", err)
+	}
+
+	result := &inspection{
+		BlockRoot:               encodeHexBytes(blockRoot[:]),
+		Slot:                    block.Message.Slot,
+		ExecutionPayloadHeader:  header.ExecutionPayloadHeader,
+		VersionedHashes:         make([]string, 0, len(header.VersionedHashes)),
+		ExecutionRequests:       header.ExecutionRequests,
+		NewPayloadRequestHeader: encodeHexBytes(headerSSZ),
+		NewPayloadRequestRoot:   encodeHexBytes(root[:]),
+	}
+
+	for _, versionedHash := range header.VersionedHashes {
+		result.VersionedHashes = append(result.VersionedHashes, encodeHexBytes(versionedHash))
+	}
+
+	for proofType, generator := range generators {
+		proofData, err := generator.GenerateProofData(ctx, &ProofRequest{
+			Block:                   block,
+			ProofType:               ProofType(proofType),
+			NewPayloadRequestHeader: header,
+			NewPayloadRequestRoot:   root,
+		})
+		if err != nil {
+			return fmt.Errorf("generate proof data %d: %w", proofType, err)
+		}
+
+		result.Proofs = append(result.Proofs, &inspectedProof{
+			ProofType: strconv.Itoa(proofType),
+			ProofData: encodeHexBytes(proofData),
+		})
+	}
+
+	if format == inspectFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	return result.writeText(w)
+}
+
+// writeText writes the inspection to w as human-readable text.
+func (i *inspection) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	payload := i.ExecutionPayloadHeader
+	baseFeePerGas, err := formatQuotedUint256(payload.BaseFeePerGas)
+	if err != nil {
+		return fmt.Errorf("format base_fee_per_gas: %w", err)
+	}
+
+	fmt.Fprintf(tw, "Block\n")
+	fmt.Fprintf(tw, "  root\t%s\n", i.BlockRoot)
+	fmt.Fprintf(tw, "  slot\t%d\n", i.Slot)
+
+	fmt.Fprintf(tw, "\nExecution payload header\n")
+	fmt.Fprintf(tw, "  parent_hash\t%#x\n", payload.ParentHash)
+	fmt.Fprintf(tw, "  fee_recipient\t%#x\n", payload.FeeRecipient)
+	fmt.Fprintf(tw, "  state_root\t%#x\n", payload.StateRoot)
+	fmt.Fprintf(tw, "  receipts_root\t%#x\n", payload.ReceiptsRoot)
+	fmt.Fprintf(tw, "  logs_bloom\t%#x\n", payload.LogsBloom)
+	fmt.Fprintf(tw, "  prev_randao\t%#x\n", payload.PrevRandao)
+	fmt.Fprintf(tw, "  block_number\t%d\n", payload.BlockNumber)
+	fmt.Fprintf(tw, "  gas_limit\t%d\n", payload.GasLimit)
+	fmt.Fprintf(tw, "  gas_used\t%d\n", payload.GasUsed)
+	fmt.Fprintf(tw, "  timestamp\t%d\n", payload.Timestamp)
+	fmt.Fprintf(tw, "  extra_data\t%s\n", encodeHexBytes(payload.ExtraData))
+	fmt.Fprintf(tw, "  base_fee_per_gas\t%s\n", baseFeePerGas)
+	fmt.Fprintf(tw, "  block_hash\t%#x\n", payload.BlockHash)
+	fmt.Fprintf(tw, "  transactions_root\t%#x\n", payload.TransactionsRoot)
+	fmt.Fprintf(tw, "  withdrawals_root\t%#x\n", payload.WithdrawalsRoot)
+	fmt.Fprintf(tw, "  blob_gas_used\t%d\n", payload.BlobGasUsed)
+	fmt.Fprintf(tw, "  excess_blob_gas\t%d\n", payload.ExcessBlobGas)
+
+	fmt.Fprintf(tw, "\nVersioned hashes (%d)\n", len(i.VersionedHashes))
+	for index, versionedHash := range i.VersionedHashes {
+		fmt.Fprintf(tw, "  %d\t%s\n", index, versionedHash)
+	}
+
+	requests := i.ExecutionRequests
+	fmt.Fprintf(tw, "\nExecution requests\n")
+	fmt.Fprintf(tw, "  deposits (%d)\n", len(requests.Deposits))
+	for index, deposit := range requests.Deposits {
+		fmt.Fprintf(tw, "    %d\tpubkey=%#x withdrawal_credentials=%#x amount=%d signature=%#x index=%d\n", index, deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Amount, deposit.Signature, deposit.Index)
+	}
+	fmt.Fprintf(tw, "  withdrawals (%d)\n", len(requests.Withdrawals))
+	for index, withdrawal := range requests.Withdrawals {
+		fmt.Fprintf(tw, "    %d\tsource_address=%#x validator_pubkey=%#x amount=%d\n", index, withdrawal.SourceAddress, withdrawal.ValidatorPubkey, withdrawal.Amount)
+	}
+	fmt.Fprintf(tw, "  consolidations (%d)\n", len(requests.Consolidations))
+	for index, consolidation := range requests.Consolidations {
+		fmt.Fprintf(tw, "    %d\tsource_address=%#x source_pubkey=%#x target_pubkey=%#x\n", index, consolidation.SourceAddress, consolidation.SourcePubkey, consolidation.TargetPubkey)
+	}
+
+	fmt.Fprintf(tw, "\nNew payload request header\n")
+	fmt.Fprintf(tw, "  root\t%s\n", i.NewPayloadRequestRoot)
+	fmt.Fprintf(tw, "  ssz\t%s\n", i.NewPayloadRequestHeader)
+
+	fmt.Fprintf(tw, "\nProof data\n")
+	for _, proof := range i.Proofs {
+		fmt.Fprintf(tw, "  %s\t%s\n", proof.ProofType, proof.ProofData)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+type (
+	// keystore is an EIP-2335 BLS keystore.
+	keystore struct {
+		Crypto  keystoreCrypto `json:"crypto"`
+		Pubkey  string         `json:"pubkey"`
+		Path    string         `json:"path"`
+		Version int            `json:"version"`
+	}
+
+	keystoreCrypto struct {
+		KDF      keystoreModule `json:"kdf"`
+		Checksum keystoreModule `json:"checksum"`
+		Cipher   keystoreModule `json:"cipher"`
+	}
+
+	keystoreModule struct {
+		Function string          `json:"function"`
+		Params   json.RawMessage `json:"params"`
+		Message  string          `json:"message"`
+	}
+
+	scryptParams struct {
+		DKLen int    `json:"dklen"`
+		N     int    `json:"n"`
+		R     int    `json:"r"`
+		P     int    `json:"p"`
+		Salt  string `json:"salt"`
+	}
+
+	pbkdf2Params struct {
+		DKLen int    `json:"dklen"`
+		C     int    `json:"c"`
+		PRF   string `json:"prf"`
+		Salt  string `json:"salt"`
+	}
+
+	aes128CTRParams struct {
+		IV string `json:"iv"`
+	}
+)
+
+// decryptKeystore decrypts an EIP-2335 keystore and returns its secret key.
+func decryptKeystore(data []byte, password string) (*blsSecretKey, error) {
+	var ks keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("unmarshal keystore: %w", err)
+	}
+
+	if ks.Version != 4 {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+
+	decryptionKey, err := deriveKeystoreKey(ks.Crypto.KDF, normalizeKeystorePassword(password))
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	if len(decryptionKey) < 32 {
+		return nil, fmt.Errorf("derived key too short: %d bytes", len(decryptionKey))
+	}
+
+	cipherMessage, err := decodeHexBytes(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("decode cipher message: %w", err)
+	}
+
+	if ks.Crypto.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("unsupported checksum function %q", ks.Crypto.Checksum.Function)
+	}
+
+	expectedChecksum, err := decodeHexBytes(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("decode checksum: %w", err)
+	}
+
+	checksum := sha256.Sum256(append(bytes.Clone(decryptionKey[16:32]), cipherMessage...))
+	if !bytes.Equal(checksum[:], expectedChecksum) {
+		return nil, errors.New("checksum mismatch, wrong password?")
+	}
+
+	if ks.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher function %q", ks.Crypto.Cipher.Function)
+	}
+
+	var params aes128CTRParams
+	if err := json.Unmarshal(ks.Crypto.Cipher.Params, &params); err != nil {
+		return nil, fmt.Errorf("unmarshal cipher params: %w", err)
+	}
+
+	iv, err := decodeHexBytes(params.IV)
+	if err != nil {
+		return nil, fmt.Errorf("decode iv: %w", err)
+	}
+
+	block, err := aes.NewCipher(decryptionKey[:16])
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %w", err)
+	}
+
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("invalid iv length: got %d, want %d", len(iv), block.BlockSize())
+	}
+
+	secret := make([]byte, len(cipherMessage))
+	cipher.NewCTR(block, iv).XORKeyStream(secret, cipherMessage)
+
+	secretKey, err := newBLSSecretKey(secret)
+	if err != nil {
+		return nil, fmt.Errorf("secret key: %w", err)
+	}
+
+	if ks.Pubkey != "" {
+		pubkey, err := decodeHexBytes(ks.Pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("decode pubkey: %w", err)
+		}
+
+		if !bytes.Equal(pubkey, secretKey.PublicKey()) {
+			return nil, errors.New("decrypted secret key does not match the keystore pubkey")
+		}
+	}
+
+	return secretKey, nil
+}
+
+// deriveKeystoreKey runs the keystore key derivation function on password.
+func deriveKeystoreKey(kdf keystoreModule, password []byte) ([]byte, error) {
+	switch kdf.Function {
+	case "scrypt":
+		var params scryptParams
+		if err := json.Unmarshal(kdf.Params, &params); err != nil {
+			return nil, fmt.Errorf("unmarshal scrypt params: %w", err)
+		}
+
+		salt, err := decodeHexBytes(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("decode salt: %w", err)
+		}
+
+		return scrypt.Key(password, salt, params.N, params.R, params.P, params.DKLen)
+
+	case "pbkdf2":
+		var params pbkdf2Params
+		if err := json.Unmarshal(kdf.Params, &params); err != nil {
+			return nil, fmt.Errorf("unmarshal pbkdf2 params: %w", err)
+		}
+
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 prf %q", params.PRF)
+		}
+
+		salt, err := decodeHexBytes(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("decode salt: %w", err)
+		}
+
+		return pbkdf2.Key(sha256.New, string(password), salt, params.C, params.DKLen)
+
+	default:
+		return nil, fmt.Errorf("unsupported kdf function %q", kdf.Function)
+	}
+}
+
+// normalizeKeystorePassword applies the EIP-2335 password processing:
+// NFKD normalization, then removal of the C0, C1 and Delete control codes.
+func normalizeKeystorePassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+
+	return []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7F && r <= 0x9F) {
+			return -1
+		}
+		return r
+	}, normalized))
+}
+
+// localKey is a decrypted validator key.
+type localKey struct {
+	secretKey *blsSecretKey
+	pubkey    []byte
+}
+
+// KeystoreSigner signs execution proofs locally with keys decrypted from EIP-2335 keystores.
+// Proofs are signed by each key in turn.
+type KeystoreSigner struct {
+	keys    []*localKey
+	indices *validatorIndexCache
+	domain  *signingDomainProvider
+	next    atomic.Uint64
+}
+
+// NewKeystoreSigner decrypts every keystore of dir with the password stored in passwordFile.
+// indices resolves the validator indices of the keys, domain the signing domain.
+func NewKeystoreSigner(dir string, passwordFile string, indices *validatorIndexCache, domain *signingDomainProvider) (*KeystoreSigner, error) {
+	passwordBytes, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("read password file: %w", err)
+	}
+
+	password := strings.TrimRight(string(passwordBytes), "\r\n")
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list keystores: %w", err)
+	}
+
+	keys := make([]*localKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read keystore %s: %w", path, err)
+		}
+
+		secretKey, err := decryptKeystore(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt keystore %s: %w", path, err)
+		}
+
+		keys = append(keys, &localKey{
+			secretKey: secretKey,
+			pubkey:    secretKey.PublicKey(),
+		})
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keystore found in %s", dir)
+	}
+
+	return &KeystoreSigner{
+		keys:    keys,
+		indices: indices,
+		domain:  domain,
+	}, nil
+}
+
+// SignExecutionProof implements Signer.
+func (s *KeystoreSigner) SignExecutionProof(ctx context.Context, proof *ExecutionProof) (*SignedExecutionProof, error) {
+	key := s.keys[(s.next.Add(1)-1)%uint64(len(s.keys))]
+
+	validatorIndex, err := s.indices.get(ctx, key.pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("validator index of %#x: %w", key.pubkey, err)
+	}
+
+	signingRoot, err := s.domain.signingRoot(ctx, proof)
+	if err != nil {
+		return nil, fmt.Errorf("signing root: %w", err)
+	}
+
+	signature, err := key.secretKey.Sign(signingRoot[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	return &SignedExecutionProof{
+		Message:        proof,
+		ValidatorIndex: validatorIndex,
+		Signature:      signature,
+	}, nil
+}
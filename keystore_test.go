@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// testKeystorePassword is the password of the EIP-2335 test keystores.
+	testKeystorePassword = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+
+	testKeystoreSecret = "0x000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	testKeystorePubkey = "0x9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+)
+
+// TestDecryptKeystore decrypts the EIP-2335 test keystores.
+func TestDecryptKeystore(t *testing.T) {
+	for _, file := range []string{"scrypt.json", "pbkdf2.json"} {
+		t.Run(file, func(t *testing.T) {
+			secretKey, err := decryptKeystore(readKeystoreFixture(t, file, nil), testKeystorePassword)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertBytes(t, "secret", secretKey.scalar.FillBytes(make([]byte, 32)), testKeystoreSecret)
+			assertBytes(t, "pubkey", secretKey.PublicKey(), testKeystorePubkey)
+		})
+	}
+}
+
+func TestDecryptKeystoreErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		file     string
+		password string
+		mutate   func(crypto map[string]any)
+		wantErr  string
+	}{
+		{name: "wrong scrypt password", file: "scrypt.json", password: "testpassword", wantErr: "checksum mismatch"},
+		{name: "wrong pbkdf2 password", file: "pbkdf2.json", password: "testpassword", wantErr: "checksum mismatch"},
+		{
+			name:     "unsupported kdf",
+			file:     "pbkdf2.json",
+			password: testKeystorePassword,
+			mutate:   func(crypto map[string]any) { cryptoModule(crypto, "kdf")["function"] = "argon2id" },
+			wantErr:  `unsupported kdf function "argon2id"`,
+		},
+		{
+			name:     "unsupported prf",
+			file:     "pbkdf2.json",
+			password: testKeystorePassword,
+			mutate: func(crypto map[string]any) {
+				cryptoModule(crypto, "kdf")["params"].(map[string]any)["prf"] = "hmac-sha512"
+			},
+			wantErr: `unsupported pbkdf2 prf "hmac-sha512"`,
+		},
+		{
+			name:     "unsupported cipher",
+			file:     "pbkdf2.json",
+			password: testKeystorePassword,
+			mutate:   func(crypto map[string]any) { cryptoModule(crypto, "cipher")["function"] = "aes-256-gcm" },
+			wantErr:  `unsupported cipher function "aes-256-gcm"`,
+		},
+		{
+			name:     "unsupported checksum",
+			file:     "pbkdf2.json",
+			password: testKeystorePassword,
+			mutate:   func(crypto map[string]any) { cryptoModule(crypto, "checksum")["function"] = "sha512" },
+			wantErr:  `unsupported checksum function "sha512"`,
+		},
+		{
+			name:     "tampered checksum",
+			file:     "pbkdf2.json",
+			password: testKeystorePassword,
+			mutate: func(crypto map[string]any) {
+				cryptoModule(crypto, "checksum")["message"] = "9a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+			},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:     "tampered cipher message",
+			file:     "pbkdf2.json",
+			password: testKeystorePassword,
+			mutate: func(crypto map[string]any) {
+				cryptoModule(crypto, "cipher")["message"] = "dee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+			},
+			wantErr: "checksum mismatch",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := decryptKeystore(readKeystoreFixture(t, tc.file, tc.mutate), tc.password)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecryptKeystoreWrongPubkey(t *testing.T) {
+	data := readKeystoreFixture(t, "pbkdf2.json", nil)
+
+	var ks map[string]any
+	if err := json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+	ks["pubkey"] = "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a"
+
+	_, err := decryptKeystore(mustMarshalJSON(t, ks), testKeystorePassword)
+	if err == nil || !strings.Contains(err.Error(), "does not match the keystore pubkey") {
+		t.Fatalf("error: got %v, want a pubkey mismatch", err)
+	}
+}
+
+func TestNormalizeKeystorePassword(t *testing.T) {
+	if got, want := string(normalizeKeystorePassword(testKeystorePassword)), "testpassword🔑"; got != want {
+		t.Errorf("normalized password: got %q, want %q", got, want)
+	}
+
+	if got, want := string(normalizeKeystorePassword("pass\x00word\x7f\u0085\n")), "password"; got != want {
+		t.Errorf("normalized password: got %q, want %q", got, want)
+	}
+}
+
+func TestNewKeystoreSigner(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	if err := os.WriteFile(passwordFile, []byte(testKeystorePassword+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewKeystoreSigner(filepath.Join("testdata", "keystores"), passwordFile, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(signer.keys) != 2 {
+		t.Fatalf("keys: got %d, want 2", len(signer.keys))
+	}
+
+	for _, key := range signer.keys {
+		assertBytes(t, "pubkey", key.pubkey, testKeystorePubkey)
+	}
+
+	if _, err := NewKeystoreSigner(t.TempDir(), passwordFile, nil, nil); err == nil {
+		t.Error("no error for a directory without keystores")
+	}
+}
+
+// readKeystoreFixture reads a testdata keystore, applying mutate to its crypto section.
+func readKeystoreFixture(t *testing.T, file string, mutate func(crypto map[string]any)) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "keystores", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mutate == nil {
+		return data
+	}
+
+	var ks map[string]any
+	if err := json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+
+	mutate(ks["crypto"].(map[string]any))
+
+	return mustMarshalJSON(t, ks)
+}
+
+// cryptoModule returns the named module of a keystore crypto section.
+func cryptoModule(crypto map[string]any, name string) map[string]any {
+	return crypto[name].(map[string]any)
+}
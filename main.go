@@ -2,57 +2,152 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/lmittmann/tint"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var logger = slog.New(tint.NewHandler(os.Stderr, &tint.Options{Level: slog.LevelInfo}))
 
-const targetBeaconNodeFlag = "target-beacon-node"
+// Subcommands, run being the default.
+const (
+	runCommand     = "run"
+	proveCommand   = "prove"
+	inspectCommand = "inspect"
+)
 
-func main() {
-	targetBeaconNode := flag.String(targetBeaconNodeFlag, "http://localhost:3500", "Beacon node HTTP endpoint to submit proofs to")
-	sourceBeaconNode := flag.String("source-beacon-node", "", fmt.Sprintf("Beacon node HTTP endpoint to source blocks from (defaults to -%s)", targetBeaconNodeFlag))
-	validatorClientURL := flag.String("validator-client", "http://localhost:7500", "Validator client HTTP endpoint for signing proofs")
-	proofsPerBlock := flag.Int("proofs-per-block", 2, "Number of proof IDs to submit per block (max 8)")
-	proofDelayMs := flag.Int("proof-delay-ms", 1000, "Delay in milliseconds to simulate proof generation time")
-	proofDelayJitterMs := flag.Int("proof-delay-jitter-ms", 0, "Random jitter in milliseconds added to proof delay (±)")
-	metricsAddr := flag.String("metrics-addr", ":8080", "Address for the metrics/health HTTP server")
+const (
+	targetBeaconNodeFlag = "target-beacon-node"
 
-	flag.Parse()
+	// defaultBeaconNodeURL is the default target, and the default source of commands without targets.
+	defaultBeaconNodeURL = "http://localhost:3500"
 
-	// Start health/metrics HTTP server
-	go startHealthServer(*metricsAddr)
+	// healthServerShutdownTimeout bounds how long open metrics and health requests are waited for on shutdown.
+	healthServerShutdownTimeout = 5 * time.Second
+)
 
-	cfg := Config{
-		TargetBeaconNode:   *targetBeaconNode,
-		SourceBeaconNode:   *sourceBeaconNode,
-		ValidatorClientURL: *validatorClientURL,
-		ProofsPerBlock:     *proofsPerBlock,
-		ProofDelayMs:       *proofDelayMs,
-		ProofDelayJitterMs: *proofDelayJitterMs,
+func main() {
+	// Without a subcommand, run the daemon as earlier versions did
+	command, args := runCommand, os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	var err error
+	switch command {
+	case runCommand:
+		err = runMain(args)
+	case proveCommand:
+		err = proveMain(args)
+	case inspectCommand:
+		err = inspectMain(args)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q, want %s, %s or %s\n", command, runCommand, proveCommand, inspectCommand)
+		os.Exit(2)
 	}
 
-	if err := run(cfg); err != nil {
+	if err != nil {
+		logger.Error("Command failed", "command", command, "error", err)
 		os.Exit(1)
 	}
 }
 
-func startHealthServer(addr string) {
+// runMain runs the run command: prove the blocks gossiped to the sources, as a daemon.
+func runMain(args []string) error {
+	var cfg Config
+
+	fs := flag.NewFlagSet(runCommand, flag.ExitOnError)
+	cfg.registerFlags(fs)
+	cfg.registerRunFlags(fs)
+	fs.Parse(args)
+
+	return run(cfg)
+}
+
+// registerFlags registers the flags shared by the run and prove commands, which fill cfg once fs is parsed.
+func (cfg *Config) registerFlags(fs *flag.FlagSet) {
+	cfg.registerSourceFlags(fs, "")
+	cfg.registerProofGeneratorFlags(fs)
+
+	fs.StringVar(&cfg.TargetBeaconNode, targetBeaconNodeFlag, defaultBeaconNodeURL, "Comma-separated beacon node HTTP endpoints to submit proofs to")
+	fs.StringVar(&cfg.SubmissionPolicy, "submission-policy", submissionPolicyAll, fmt.Sprintf("How many targets must accept a proof for it to count as submitted: %s, %s (strict majority) or %s", submissionPolicyAny, submissionPolicyQuorum, submissionPolicyAll))
+	fs.StringVar(&cfg.Output, "output", "", "Directory (one JSON file per proof) or JSON lines file to write signed proofs to, instead of submitting them to the target beacon nodes")
+	fs.StringVar(&cfg.ValidatorClientURL, "validator-client", "http://localhost:7500", "Validator client HTTP endpoint for signing proofs")
+	fs.StringVar(&cfg.KeystoreDir, "keystore-dir", "", "Directory of EIP-2335 keystores to sign proofs locally instead of using the validator client")
+	fs.StringVar(&cfg.KeystorePasswordFile, "keystore-password-file", "", "File containing the password of the keystores in -keystore-dir")
+	fs.StringVar(&cfg.Web3SignerURL, "web3signer-url", "", "Web3Signer HTTP endpoint to sign proofs with instead of using the validator client")
+	fs.StringVar(&cfg.Web3SignerPubkeys, "web3signer-pubkeys", "", "Comma-separated public keys to sign with in Web3Signer (defaults to every available key)")
+	fs.StringVar(&cfg.ExecutionProofDomain, "execution-proof-domain", defaultExecutionProofDomainType, "Domain type used to compute the execution proof signing root with keystores or Web3Signer")
+	fs.StringVar(&cfg.GenesisValidatorsRoot, "genesis-validators-root", "", fmt.Sprintf("Genesis validators root of the signing domain with keystores or Web3Signer (defaults to the first -%s's)", targetBeaconNodeFlag))
+	fs.StringVar(&cfg.ForkVersion, "fork-version", "", fmt.Sprintf("Fork version of the signing domain with keystores or Web3Signer (defaults to the first -%s's current one)", targetBeaconNodeFlag))
+	fs.StringVar(&cfg.ValidatorIndices, "validator-indices", "", fmt.Sprintf("Comma-separated <pubkey>=<index> validator indices of the keystore or Web3Signer keys (others are looked up from the first -%s)", targetBeaconNodeFlag))
+	fs.StringVar(&cfg.FaultInjection, "fault-injection", "", fmt.Sprintf("Comma-separated [<proof type>:]<fault>=<probability> entries to deliberately corrupt proofs (e.g. bad-signature=0.1,1:wrong-root=0.5). Faults: %s", strings.Join(faults, ", ")))
+	fs.StringVar(&cfg.Scenario, "scenario", "", "YAML or JSON file overriding proofs per block, delay, targets, withholding and fault injection for some slots or epochs (disabled if empty)")
+	fs.IntVar(&cfg.ProofDelayMs, "proof-delay-ms", 1000, "Delay in milliseconds to simulate proof generation time")
+	fs.IntVar(&cfg.ProofDelayJitterMs, "proof-delay-jitter-ms", 0, "Random jitter in milliseconds added to proof delay (±)")
+	fs.IntVar(&cfg.Workers, "workers", 4, "Number of blocks processed concurrently")
+	fs.IntVar(&cfg.RetryBudget, "retry-budget", 16, "Maximum number of request retries across all the requests made for a block")
+	fs.StringVar(&cfg.DataDir, "data-dir", "", "Directory of the outbox recording signed proofs until they are submitted, resubmitted on the next start (disabled if empty)")
+}
+
+// registerSourceFlags registers the flags selecting the source of blocks, which fill cfg once fs is parsed.
+// Without defaultSource, blocks are sourced from the first target.
+func (cfg *Config) registerSourceFlags(fs *flag.FlagSet, defaultSource string) {
+	sourceUsage := "Comma-separated beacon node HTTP endpoints to source blocks from, in order of preference"
+	if defaultSource == "" {
+		sourceUsage += fmt.Sprintf(" (defaults to the first -%s)", targetBeaconNodeFlag)
+	}
+
+	fs.StringVar(&cfg.SourceFiles, "source-files", "", "Directory of signed blinded block files (.json or .ssz) or JSON lines file of blocks to prove offline, instead of sourcing them from beacon nodes")
+	fs.StringVar(&cfg.SourceBeaconNode, "source-beacon-node", defaultSource, sourceUsage)
+	fs.StringVar(&cfg.BlockEncoding, "block-encoding", blockEncodingSSZ, fmt.Sprintf("Encoding to fetch blocks with: %s (falling back to JSON when unsupported) or %s", blockEncodingSSZ, blockEncodingJSON))
+	fs.Uint64Var(&cfg.SourceMaxHeadLagSlots, "source-max-head-lag-slots", 3, "Fail over to another source when the active one's head is this many slots behind another's (0 disables this check)")
+	fs.IntVar(&cfg.RetryMaxAttempts, "retry-max-attempts", 3, "Maximum number of attempts of each beacon node, validator client and Web3Signer request failing with a rate limit, server or transport error")
+}
+
+// registerProofGeneratorFlags registers the flags selecting the proof generator of each proof type,
+// which fill cfg once fs is parsed.
+func (cfg *Config) registerProofGeneratorFlags(fs *flag.FlagSet) {
+	fs.IntVar(&cfg.ProofsPerBlock, "proofs-per-block", 2, fmt.Sprintf("Number of proof IDs to submit per block (max %d)", proofTypeCount))
+	fs.StringVar(&cfg.ProofGenerator, "proof-generator", dummyProofGeneratorName, "Proof generator backend: a single backend for every proof type, or comma-separated <proof type>=<backend> overrides (e.g. dummy,1=random). Backends: dummy, random, exec")
+	fs.IntVar(&cfg.RandomProofSize, "random-proof-size", 1024, "Size in bytes of the proof data produced by the random proof generator")
+	fs.StringVar(&cfg.ExecProofCommand, "exec-proof-command", "", "Command run by the exec proof generator, {proof_type} and {input} are substituted (input is passed on stdin unless {input} is used)")
+	fs.IntVar(&cfg.ExecProofTimeoutMs, "exec-proof-timeout-ms", 30000, "Timeout in milliseconds for each exec proof generator command")
+	fs.IntVar(&cfg.ExecProofConcurrency, "exec-proof-concurrency", 1, "Maximum number of concurrent exec proof generator commands per proof type")
+}
+
+// registerRunFlags registers the flags of the run command only.
+func (cfg *Config) registerRunFlags(fs *flag.FlagSet) {
+	fs.IntVar(&cfg.QueueDepth, "queue-depth", 16, "Maximum number of block events waiting for a worker")
+	fs.StringVar(&cfg.QueueOverflow, "queue-overflow", overflowDropOldest, fmt.Sprintf("What to do with a block event when the queue is full: %s, %s or %s", overflowDropOldest, overflowDropNewest, overflowBlock))
+	fs.Uint64Var(&cfg.BlockDeadlineSlots, "block-deadline-slots", 2, "Abandon a live block once this many slots have started after its own (0 disables the deadline)")
+	fs.IntVar(&cfg.ShutdownGracePeriodMs, "shutdown-grace-period-ms", 15000, "Time in milliseconds given to queued and in-flight blocks to be submitted on shutdown")
+	fs.Uint64Var(&cfg.OutboxMaxAgeSlots, "outbox-max-age-slots", 32, "Pending proofs of blocks older than this many slots are given up on instead of resubmitted")
+	fs.StringVar(&cfg.MetricsAddr, "metrics-addr", ":8080", "Address for the metrics/health HTTP server")
+	fs.Uint64Var(&cfg.ReadinessMaxSlotLag, "readiness-max-slot-lag", 32, "Maximum number of slots since the last processed block for /readyz to pass (0 disables this check)")
+}
+
+// startHealthServer serves metrics and health probes on addr in the background.
+func startHealthServer(addr string, health *Health) *http.Server {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	mux.Handle("/metrics", promhttp.Handler())
+
+	mux.HandleFunc("/livez", health.handler(health.livez))
+	mux.HandleFunc("/readyz", health.handler(health.readyz))
+
+	// Kept for compatibility, same as /livez
+	mux.HandleFunc("/health", health.handler(health.livez))
 
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -65,80 +160,369 @@ func startHealthServer(addr string) {
 	}
 
 	logger.Info("Starting health server", "addr", addr)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		logger.Error("Health server error", "error", err)
-	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("Health server error", "error", err)
+		}
+	}()
+
+	return server
 }
 
 // Config holds the configuration for the dummy prover.
 type Config struct {
-	TargetBeaconNode   string
-	SourceBeaconNode   string
-	ValidatorClientURL string
-	ProofsPerBlock     int
-	ProofDelayMs       int
-	ProofDelayJitterMs int
+	TargetBeaconNode      string
+	SubmissionPolicy      string
+	SourceBeaconNode      string
+	SourceFiles           string
+	Output                string
+	SourceMaxHeadLagSlots uint64
+	BlockEncoding         string
+	ValidatorClientURL    string
+	KeystoreDir           string
+	KeystorePasswordFile  string
+	Web3SignerURL         string
+	Web3SignerPubkeys     string
+	ExecutionProofDomain  string
+	GenesisValidatorsRoot string
+	ForkVersion           string
+	ValidatorIndices      string
+	ProofsPerBlock        int
+	ProofGenerator        string
+	RandomProofSize       int
+	ExecProofCommand      string
+	ExecProofTimeoutMs    int
+	ExecProofConcurrency  int
+	FaultInjection        string
+	Scenario              string
+	ProofDelayMs          int
+	ProofDelayJitterMs    int
+	Workers               int
+	QueueDepth            int
+	QueueOverflow         string
+	BlockDeadlineSlots    uint64
+	ShutdownGracePeriodMs int
+	RetryMaxAttempts      int
+	RetryBudget           int
+	DataDir               string
+	OutboxMaxAgeSlots     uint64
+	MetricsAddr           string
+	ReadinessMaxSlotLag   uint64
 }
 
-func run(cfg Config) error {
-	// Use beacon-node as source if not specified
-	sourceURL := cfg.SourceBeaconNode
-	if sourceURL == "" {
-		sourceURL = cfg.TargetBeaconNode
-	}
+// components are the parts of the prover shared by every command.
+type components struct {
+	sources           BlockSource
+	submitter         *Submitter
+	signer            Signer
+	signerDescription string
+	sink              *FileSink
+	outbox            *Outbox
+	clock             *lazySlotClock
+	prover            *Prover
+}
 
+// newComponents creates the components configured by cfg.
+// The caller must close them.
+func newComponents(cfg Config) (*components, error) {
 	// Create beacon clients
-	target := NewBeaconClient(cfg.TargetBeaconNode)
-	source := NewBeaconClient(sourceURL)
+	retry := newRetryPolicy(cfg.RetryMaxAttempts)
+	targets := parseBeaconClients(cfg.TargetBeaconNode, retry)
+	if len(targets) == 0 && cfg.Output == "" {
+		logger.Error("Invalid target configuration", "error", "no target beacon node")
+		return nil, errors.New("no target beacon node")
+	}
 
-	// Create validator client for signing
-	validatorClient := NewValidatorClient(cfg.ValidatorClientURL)
+	// Write proofs to files instead of submitting them, if enabled
+	var sink *FileSink
+	proofTargets := make([]proofTarget, 0, len(targets))
+	if cfg.Output != "" {
+		var err error
+		sink, err = OpenFileSink(cfg.Output)
+		if err != nil {
+			logger.Error("Failed to open output", "error", err)
+			return nil, fmt.Errorf("open file sink: %w", err)
+		}
+		proofTargets = append(proofTargets, sink)
+	} else {
+		for _, target := range targets {
+			proofTargets = append(proofTargets, target)
+		}
+	}
+
+	submitter, err := NewSubmitter(proofTargets, cfg.SubmissionPolicy)
+	if err != nil {
+		logger.Error("Invalid target configuration", "error", err)
+		return nil, fmt.Errorf("new submitter: %w", err)
+	}
+
+	sources, err := newBlockSource(cfg, retry)
+	if err != nil {
+		logger.Error("Invalid source configuration", "error", err)
+		return nil, fmt.Errorf("new block source: %w", err)
+	}
+
+	// Create signer, resolving signing data from the first target, if any.
+	// Proofs written to files have no target, whatever -target-beacon-node defaults to.
+	var signingClient *BeaconClient
+	if len(targets) > 0 && cfg.Output == "" {
+		signingClient = targets[0]
+	}
+
+	signer, signerDescription, err := newSigner(cfg, signingClient, retry)
+	if err != nil {
+		logger.Error("Failed to create signer", "error", err)
+		return nil, fmt.Errorf("new signer: %w", err)
+	}
+
+	// Create one proof generator per proof type
+	generators, err := newProofGenerators(cfg.ProofGenerator, cfg.ProofsPerBlock, cfg)
+	if err != nil {
+		logger.Error("Invalid proof generator configuration", "error", err)
+		return nil, fmt.Errorf("new proof generators: %w", err)
+	}
+
+	// Create the fault injector, if enabled
+	faultInjector, err := newFaultInjector(cfg.FaultInjection, cfg.ProofsPerBlock)
+	if err != nil {
+		logger.Error("Invalid fault injection configuration", "error", err)
+		return nil, fmt.Errorf("new fault injector: %w", err)
+	}
+
+	// Open the outbox, if enabled
+	var outbox *Outbox
+	if cfg.DataDir != "" {
+		outbox, err = OpenOutbox(cfg.DataDir)
+		if err != nil {
+			logger.Error("Failed to open outbox", "error", err)
+			return nil, fmt.Errorf("open outbox: %w", err)
+		}
+	}
+
+	clock := newLazySlotClock(sources)
+
+	// Load the scenario, if enabled
+	var scenario *Scenario
+	if cfg.Scenario != "" {
+		scenario, err = LoadScenario(cfg.Scenario, cfg.ProofsPerBlock, submitter, clock)
+		if err != nil {
+			logger.Error("Invalid scenario", "path", cfg.Scenario, "error", err)
+			return nil, fmt.Errorf("load scenario: %w", err)
+		}
+	}
 
 	// Create prover
-	prover := NewProver(source, target, validatorClient, cfg.ProofsPerBlock, time.Duration(cfg.ProofDelayMs)*time.Millisecond, time.Duration(cfg.ProofDelayJitterMs)*time.Millisecond)
+	prover := NewProver(sources, submitter, signer, generators, outbox, faultInjector, scenario, time.Duration(cfg.ProofDelayMs)*time.Millisecond, time.Duration(cfg.ProofDelayJitterMs)*time.Millisecond, cfg.RetryBudget)
+
+	return &components{
+		sources:           sources,
+		submitter:         submitter,
+		signer:            signer,
+		signerDescription: signerDescription,
+		sink:              sink,
+		outbox:            outbox,
+		clock:             clock,
+		prover:            prover,
+	}, nil
+}
+
+// newBlockSource creates the source of blocks: the files of cfg.SourceFiles if set, else
+// the beacon nodes of cfg.SourceBeaconNode, defaulting to the first target.
+func newBlockSource(cfg Config, retry retryPolicy) (BlockSource, error) {
+	if cfg.SourceFiles != "" {
+		source, err := OpenFileSource(cfg.SourceFiles)
+		if err != nil {
+			return nil, fmt.Errorf("open file source: %w", err)
+		}
+
+		return source, nil
+	}
+
+	// Use the first target as source if not specified
+	sourceURLs := cfg.SourceBeaconNode
+	if sourceURLs == "" {
+		sourceURLs, _, _ = strings.Cut(cfg.TargetBeaconNode, ",")
+	}
+
+	pool, err := NewSourcePool(parseBeaconClients(sourceURLs, retry), cfg.SourceMaxHeadLagSlots, cfg.BlockEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("new source pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+// Close closes the outbox and the output file.
+func (c *components) Close() {
+	if err := c.outbox.Close(); err != nil {
+		logger.Error("Failed to close outbox", "error", err)
+	}
+
+	if err := c.sink.Close(); err != nil {
+		logger.Error("Failed to close output", "error", err)
+	}
+}
+
+func run(cfg Config) error {
+	c, err := newComponents(cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// Blocks read from files are all proved, however many and however old
+	offline := cfg.SourceFiles != ""
+	if offline {
+		cfg.QueueOverflow = overflowBlock
+		cfg.BlockDeadlineSlots = 0
+	}
 
 	logger.Info("Starting dummy prover",
-		"source", sourceURL,
-		"target", cfg.TargetBeaconNode,
-		"validatorClient", cfg.ValidatorClientURL,
+		"sources", c.sources.URLs(),
+		"blockEncoding", cfg.BlockEncoding,
+		"targets", c.submitter.URLs(),
+		"submissionPolicy", cfg.SubmissionPolicy,
+		"signer", c.signerDescription,
 		"proofsPerBlock", cfg.ProofsPerBlock,
+		"proofGenerator", cfg.ProofGenerator,
+		"faultInjection", cfg.FaultInjection,
+		"scenario", cfg.Scenario,
 		"proofDelayMs", cfg.ProofDelayMs,
 		"proofDelayJitterMs", cfg.ProofDelayJitterMs,
+		"workers", cfg.Workers,
+		"queueDepth", cfg.QueueDepth,
+		"queueOverflow", cfg.QueueOverflow,
+		"blockDeadlineSlots", cfg.BlockDeadlineSlots,
+		"retryMaxAttempts", cfg.RetryMaxAttempts,
+		"retryBudget", cfg.RetryBudget,
+		"dataDir", cfg.DataDir,
 	)
 
-	// Set up context with cancellation
+	// Start health/metrics HTTP server
+	pool, _ := c.sources.(*SourcePool)
+	health := NewHealth(pool, c.submitter, c.signer, c.clock, cfg.ReadinessMaxSlotLag)
+	server := startHealthServer(cfg.MetricsAddr, health)
+
+	// ctx stops the intake of new blocks, workCtx the processing of in-flight ones
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Handle shutdown signals
+	workCtx, cancelWork := context.WithCancel(context.Background())
+	defer cancelWork()
+
+	// Handle shutdown signals: the first one starts a graceful shutdown, the second one cuts it short
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 
-	// Subscribe to block_gossip events from source
-	events, errs := source.subscribeToBlockGossip(ctx)
+	go func() {
+		select {
+		case sig := <-sigChan:
+			logger.Info("Shutdown requested", "signal", sig, "gracePeriodMs", cfg.ShutdownGracePeriodMs)
+			cancel()
+		case <-workCtx.Done():
+			return
+		}
+
+		select {
+		case sig := <-sigChan:
+			logger.Warn("Forced shutdown requested", "signal", sig)
+			cancelWork()
+		case <-workCtx.Done():
+		}
+	}()
+
+	// Create the worker pool processing blocks
+	pipeline, err := NewPipeline(c.prover, health, c.clock, cfg.Workers, cfg.QueueDepth, cfg.QueueOverflow, cfg.BlockDeadlineSlots)
+	if err != nil {
+		logger.Error("Invalid pipeline configuration", "error", err)
+		return fmt.Errorf("new pipeline: %w", err)
+	}
+	pipeline.Start(workCtx)
+
+	// Submit the proofs a previous run did not get to, and the ones failing since, in the background.
+	// The slot clock is unknown offline, so pending proofs are left alone.
+	resubmitted := make(chan struct{})
+	go func() {
+		defer close(resubmitted)
+
+		if !offline {
+			resubmitPending(ctx, c.outbox, c.submitter, c.clock, cfg.OutboxMaxAgeSlots)
+		}
+	}()
+
+	// Subscribe to block_gossip events from the best source, failing over as needed
+	events := c.sources.subscribeToBlockGossip(ctx)
+
+	// Report the event loop as alive even when no block arrives
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
 
 	// Main event loop
+	received := 0
+loop:
 	for {
 		select {
-		case sig := <-sigChan:
-			logger.Info("Shutdown requested", "signal", sig)
-			return nil
+		case <-heartbeat.C:
+			health.Heartbeat()
+
+		case <-ctx.Done():
+			break loop
 
 		case event, ok := <-events:
 			if !ok {
-				logger.Error("Event stream ended")
-				return nil
+				if ctx.Err() == nil && offline {
+					logger.Info("Read every block, waiting for their proofs", "count", received)
+					pipeline.Drain()
+				} else if ctx.Err() == nil {
+					logger.Error("Event stream ended")
+				}
+				break loop
 			}
+			blockEventsReceived.Inc()
+			received++
 
-			if err := prover.handleBlockGossip(ctx, event); err != nil {
-				logger.Error("Failed to handle block gossip", "blockRoot", fmt.Sprintf("%#x", event.Block), "slot", event.Slot, "error", err)
-			}
-
-		case err, ok := <-errs:
-			if ok && err != nil {
-				logger.Error("Event stream error", "error", err)
-				return err
-			}
+			pipeline.Enqueue(ctx, event)
+			health.Heartbeat()
 		}
 	}
+
+	// Stop resubmitting before the outbox is closed
+	cancel()
+	<-resubmitted
+
+	shutdown(pipeline, health, server, time.Duration(cfg.ShutdownGracePeriodMs)*time.Millisecond)
+
+	// Offline runs are checks, failing unless every block was proved
+	if processed, _ := pipeline.Summary(); offline && processed < received {
+		return fmt.Errorf("%d of %d blocks not proved", received-processed, received)
+	}
+
+	return nil
+}
+
+// shutdown lets queued and in-flight blocks finish within gracePeriod, stops the health server
+// and logs what was processed and dropped.
+func shutdown(pipeline *Pipeline, health *Health, server *http.Server, gracePeriod time.Duration) {
+	health.SetShuttingDown()
+
+	graceCtx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	pipeline.Shutdown(graceCtx)
+
+	serverCtx, cancelServer := context.WithTimeout(context.Background(), healthServerShutdownTimeout)
+	defer cancelServer()
+
+	if err := server.Shutdown(serverCtx); err != nil {
+		logger.Error("Failed to shut down health server", "error", err)
+	}
+
+	processed, dropped := pipeline.Summary()
+	logger.Info("Shutdown complete",
+		"processed", processed,
+		"droppedOverflow", dropped[dropReasonOverflow],
+		"droppedExpired", dropped[dropReasonExpired],
+		"droppedShutdown", dropped[dropReasonShutdown],
+	)
 }
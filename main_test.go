@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// writeBlockFixtures writes the testdata blocks to a directory, and returns it.
+func writeBlockFixtures(t *testing.T) string {
+	t.Helper()
+
+	blocks := t.TempDir()
+	for _, fixture := range electraBlockFixtures {
+		if err := os.WriteFile(filepath.Join(blocks, fixture.file), signedBlockFixtureJSON(t, fixture.file, "electra", nil), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return blocks
+}
+
+// TestOfflineProving proves the testdata blocks from files, signing with Web3Signer
+// and writing the proofs to a directory, without any beacon node.
+func TestOfflineProving(t *testing.T) {
+	blocks := writeBlockFixtures(t)
+
+	web3Signer := newTestWeb3Signer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var request struct {
+			ForkInfo struct {
+				Fork struct {
+					CurrentVersion string `json:"current_version"`
+				} `json:"fork"`
+				GenesisValidatorsRoot string `json:"genesis_validators_root"`
+			} `json:"fork_info"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := request.ForkInfo.Fork.CurrentVersion; got != "0x05000000" {
+			t.Errorf("fork version: got %s, want 0x05000000", got)
+		}
+
+		if got, want := request.ForkInfo.GenesisValidatorsRoot, fmt.Sprintf("%#x", testGenesisValidatorsRoot); got != want {
+			t.Errorf("genesis validators root: got %s, want %s", got, want)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"signature":"%#x"}`, testSignature)
+	})
+
+	output := t.TempDir()
+	cfg := Config{
+		SourceFiles:           blocks,
+		Output:                output,
+		SubmissionPolicy:      submissionPolicyAll,
+		Web3SignerURL:         web3Signer.baseURL,
+		Web3SignerPubkeys:     fmt.Sprintf("%#x", testPubkey),
+		ExecutionProofDomain:  defaultExecutionProofDomainType,
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", testGenesisValidatorsRoot),
+		ForkVersion:           "0x05000000",
+		ValidatorIndices:      fmt.Sprintf("%#x=42", testPubkey),
+		ProofsPerBlock:        2,
+		ProofGenerator:        dummyProofGeneratorName,
+		RetryMaxAttempts:      1,
+		RetryBudget:           1,
+	}
+
+	c, err := newComponents(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for _, fixture := range electraBlockFixtures {
+		if _, err := c.prover.handleSlot(context.Background(), fixture.slot); err != nil {
+			t.Fatalf("slot %d: %v", fixture.slot, err)
+		}
+
+		// Proofs are named after the root they commit to
+		for proofType := range cfg.ProofsPerBlock {
+			data, err := os.ReadFile(filepath.Join(output, fmt.Sprintf("%s-%d.json", fixture.newPayloadRequestRoot, proofType)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proof := new(SignedExecutionProof)
+			if err := json.Unmarshal(data, proof); err != nil {
+				t.Fatal(err)
+			}
+
+			assertBytes(t, "new payload request root", proof.Message.PublicInput.NewPayloadRequestRoot, fixture.newPayloadRequestRoot)
+
+			if proof.ValidatorIndex != 42 {
+				t.Errorf("validator index: got %d, want 42", proof.ValidatorIndex)
+			}
+		}
+	}
+}
+
+// TestOfflineSigningWithoutBeaconNode checks that writing proofs to files requests nothing
+// from -target-beacon-node, so the signing data must be set.
+func TestOfflineSigningWithoutBeaconNode(t *testing.T) {
+	var requests atomic.Int32
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	t.Cleanup(node.Close)
+
+	web3Signer := newTestWeb3Signer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"signature":"%#x"}`, testSignature)
+	})
+
+	cfg := Config{
+		TargetBeaconNode:      node.URL,
+		SourceFiles:           writeBlockFixtures(t),
+		Output:                t.TempDir(),
+		SubmissionPolicy:      submissionPolicyAll,
+		Web3SignerURL:         web3Signer.baseURL,
+		Web3SignerPubkeys:     fmt.Sprintf("%#x", testPubkey),
+		ExecutionProofDomain:  defaultExecutionProofDomainType,
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", testGenesisValidatorsRoot),
+		ForkVersion:           "0x05000000",
+		ValidatorIndices:      fmt.Sprintf("%#x=42", testPubkey),
+		ProofsPerBlock:        1,
+		ProofGenerator:        dummyProofGeneratorName,
+		RetryMaxAttempts:      1,
+		RetryBudget:           1,
+	}
+
+	for _, tc := range []struct {
+		flag  string
+		unset func(*Config)
+	}{
+		{flag: "-genesis-validators-root", unset: func(cfg *Config) { cfg.GenesisValidatorsRoot = "" }},
+		{flag: "-fork-version", unset: func(cfg *Config) { cfg.ForkVersion = "" }},
+		{flag: "-validator-indices", unset: func(cfg *Config) { cfg.ValidatorIndices = "" }},
+	} {
+		partial := cfg
+		tc.unset(&partial)
+
+		if _, err := newComponents(partial); err == nil || !strings.Contains(err.Error(), tc.flag) {
+			t.Errorf("without %s: got error %v, want it required", tc.flag, err)
+		}
+	}
+
+	c, err := newComponents(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.prover.handleSlot(context.Background(), electraBlockFixtures[0].slot); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := requests.Load(); got != 0 {
+		t.Errorf("requests to the target beacon node: got %d, want 0", got)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const metricsNamespace = "dummy_prover"
+
+// Results of a submission to a single target.
+const (
+	submissionResultSuccess = "success"
+	submissionResultFailure = "failure"
+
+	// outboxResultExpired is the result of a pending proof too old to be resubmitted.
+	outboxResultExpired = "expired"
+)
+
+// Results of the submission of a proof with injected faults.
+const (
+	faultResultRejected = "rejected"
+	faultResultAccepted = "accepted"
+)
+
+// Stages a block or a proof can fail at.
+const (
+	stageFetch    = "fetch"
+	stageGenerate = "generate"
+	stageSign     = "sign"
+	stageSubmit   = "submit"
+)
+
+var (
+	blockEventsReceived = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "block_events_received_total",
+		Help:      "Number of block events received from the source beacon node.",
+	})
+
+	proofsGenerated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "proofs_generated_total",
+		Help:      "Number of proofs generated, by proof type.",
+	}, []string{"proof_type"})
+
+	proofsSigned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "proofs_signed_total",
+		Help:      "Number of proofs signed, by proof type.",
+	}, []string{"proof_type"})
+
+	proofsSubmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "proofs_submitted_total",
+		Help:      "Number of proofs accepted by enough target beacon nodes to meet the submission policy, by proof type.",
+	}, []string{"proof_type"})
+
+	targetSubmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "target_submissions_total",
+		Help:      "Number of proof submissions to each target beacon node, by proof type and result.",
+	}, []string{"target", "proof_type", "result"})
+
+	failures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "failures_total",
+		Help:      "Number of failures, by stage and proof type (empty for block-level stages).",
+	}, []string{"stage", "proof_type"})
+
+	blocksFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "blocks_fetched_total",
+		Help:      "Number of blocks fetched from source beacon nodes, by encoding.",
+	}, []string{"encoding"})
+
+	faultsInjected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "faults_injected_total",
+		Help:      "Number of faults deliberately injected into proofs, by proof type and fault.",
+	}, []string{"proof_type", "fault"})
+
+	faultySubmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "faulty_submissions_total",
+		Help:      "Number of submissions of proofs with injected faults, by proof type, fault and result.",
+	}, []string{"proof_type", "fault", "result"})
+
+	outboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "outbox_pending",
+		Help:      "Number of signed proofs recorded in the outbox and not submitted yet.",
+	})
+
+	outboxResubmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "outbox_resubmissions_total",
+		Help:      "Number of pending proofs of a previous run resubmitted on startup, by result.",
+	}, []string{"result"})
+
+	blockFetchRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "block_fetch_retries_total",
+		Help:      "Number of block fetches retried because the announced block was not found yet.",
+	})
+
+	httpRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_retries_total",
+		Help:      "Number of beacon node and validator client requests retried, by operation.",
+	}, []string{"operation"})
+
+	retryBudgetExhausted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "retry_budget_exhausted_total",
+		Help:      "Number of failed requests not retried because the retry budget of their block was exhausted.",
+	})
+
+	blockFetchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "block_fetch_duration_seconds",
+		Help:      "Time to fetch a block from the source beacon node.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	signDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "sign_duration_seconds",
+		Help:      "Time to sign a proof.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	submitDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "submit_duration_seconds",
+		Help:      "Time to submit a proof until the submission policy is met or failed.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	targetSubmitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "target_submit_duration_seconds",
+		Help:      "Time to submit a proof to each target beacon node.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"target"})
+
+	endToEndDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "end_to_end_duration_seconds",
+		Help:      "Time from the block event being received to a proof being accepted.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+	})
+
+	lastProcessedSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "last_processed_slot",
+		Help:      "Slot of the last block whose proofs were all submitted.",
+	})
+
+	inFlightBlocks = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "in_flight_blocks",
+		Help:      "Number of blocks currently being processed.",
+	})
+
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "queue_depth",
+		Help:      "Number of block events waiting for a worker.",
+	})
+
+	activeSource = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "active_source",
+		Help:      "1 for the source beacon node block events are streamed from, 0 for the others.",
+	}, []string{"source"})
+
+	sourceFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "source_failovers_total",
+		Help:      "Number of switches of the block event stream to another source beacon node.",
+	})
+
+	sourceFetchFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "source_fetch_fallbacks_total",
+		Help:      "Number of block fetches retried on another source beacon node.",
+	})
+
+	blocksDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "blocks_dropped_total",
+		Help:      "Number of block events abandoned without their proofs being submitted, by reason.",
+	}, []string{"reason"})
+)
+
+// proofTypeLabel returns the metric label value of a proof type.
+func proofTypeLabel(proofType ProofType) string {
+	return fmt.Sprintf("%d", proofType)
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// outboxFileName is the name of the outbox file in the data directory.
+	outboxFileName = "outbox.jsonl"
+
+	// Kinds of outbox records.
+	outboxRecordPending = "pending"
+	outboxRecordDone    = "done"
+
+	// outboxResubmitInterval is how often pending proofs are resubmitted, and how
+	// long a proof is left to its own submission before being resubmitted.
+	outboxResubmitInterval = time.Minute
+
+	// outboxResubmitConcurrency bounds the proofs resubmitted at once.
+	outboxResubmitConcurrency = 4
+
+	// outboxCompactThreshold is how many proofs are marked as submitted before
+	// the outbox file is compacted again.
+	outboxCompactThreshold = 1024
+)
+
+// outboxRecord is a line of the outbox file.
+// A pending record holds a signed proof to submit, a done record marks the proof with the same ID as submitted.
+type outboxRecord struct {
+	Kind  string                `json:"kind"`
+	ID    string                `json:"id"`
+	Slot  Slot                  `json:"slot,omitempty"`
+	Proof *SignedExecutionProof `json:"proof,omitempty"`
+
+	// addedAt is when the record was added, zero if it was loaded from a previous run.
+	addedAt time.Time
+}
+
+// Outbox records signed proofs on disk before they are submitted, so that the
+// proofs not submitted yet survive a restart. It is an append-only JSON lines
+// file, compacted to the pending proofs when opened and then every
+// outboxCompactThreshold submitted proofs.
+// A nil *Outbox is a disabled outbox, recording nothing.
+type Outbox struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	pending map[string]*outboxRecord
+
+	// done counts the done records appended since the file was last compacted.
+	done int
+}
+
+// OpenOutbox opens the outbox of dataDir, creating the directory if needed,
+// and loads the proofs still pending from a previous run.
+func OpenOutbox(dataDir string) (*Outbox, error) {
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return nil, fmt.Errorf("create data directory: %w", err)
+	}
+
+	path := filepath.Join(dataDir, outboxFileName)
+
+	pending, err := loadOutbox(path)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", path, err)
+	}
+
+	if err := compactOutbox(path, pending); err != nil {
+		return nil, fmt.Errorf("compact %s: %w", path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+
+	outboxPending.Set(float64(len(pending)))
+
+	return &Outbox{
+		path:    path,
+		file:    file,
+		pending: pending,
+	}, nil
+}
+
+// loadOutbox replays the outbox file at path and returns the pending records by ID.
+// Lines that cannot be parsed, such as one cut short by a crash, are skipped.
+// Lines are not size-capped, as a record holds a whole proof.
+func loadOutbox(path string) (map[string]*outboxRecord, error) {
+	pending := make(map[string]*outboxRecord)
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return pending, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(data) == 0 {
+			break
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		record := new(outboxRecord)
+		if err := json.Unmarshal(data, record); err != nil {
+			logger.Warn("Skipping invalid outbox record", "path", path, "line", line, "error", err)
+			continue
+		}
+
+		switch record.Kind {
+		case outboxRecordPending:
+			pending[record.ID] = record
+		case outboxRecordDone:
+			delete(pending, record.ID)
+		}
+	}
+
+	return pending, nil
+}
+
+// compactOutbox atomically rewrites the outbox file at path with the pending records only.
+func compactOutbox(path string, pending map[string]*outboxRecord) error {
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, record := range pending {
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// Add records a signed proof of the block at slot as pending, and returns its ID.
+// The record is synced to disk before Add returns.
+func (o *Outbox) Add(slot Slot, proof *SignedExecutionProof) (string, error) {
+	if o == nil {
+		return "", nil
+	}
+
+	root, err := proof.HashTreeRoot()
+	if err != nil {
+		return "", fmt.Errorf("proof root: %w", err)
+	}
+
+	record := &outboxRecord{
+		Kind:    outboxRecordPending,
+		ID:      fmt.Sprintf("%#x", root),
+		Slot:    slot,
+		Proof:   proof,
+		addedAt: time.Now(),
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.append(record); err != nil {
+		return "", err
+	}
+
+	o.pending[record.ID] = record
+	outboxPending.Set(float64(len(o.pending)))
+
+	return record.ID, nil
+}
+
+// Done marks the proof with the given ID as submitted.
+func (o *Outbox) Done(id string) error {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.pending[id]; !ok {
+		return nil
+	}
+
+	if err := o.append(&outboxRecord{Kind: outboxRecordDone, ID: id}); err != nil {
+		return err
+	}
+
+	delete(o.pending, id)
+	outboxPending.Set(float64(len(o.pending)))
+	o.done++
+
+	return nil
+}
+
+// compact rewrites the outbox file with the pending proofs only, once at least
+// outboxCompactThreshold proofs were marked as submitted since the last compaction.
+// The outbox keeps appending to the current file if compaction fails.
+func (o *Outbox) compact() error {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.done < outboxCompactThreshold {
+		return nil
+	}
+
+	if err := compactOutbox(o.path, o.pending); err != nil {
+		return fmt.Errorf("compact %s: %w", o.path, err)
+	}
+
+	file, err := os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", o.path, err)
+	}
+
+	if err := o.file.Close(); err != nil {
+		logger.Warn("Failed to close compacted outbox file", "path", o.path, "error", err)
+	}
+
+	o.file = file
+	o.done = 0
+
+	return nil
+}
+
+// Pending returns the proofs not submitted yet.
+func (o *Outbox) Pending() []*outboxRecord {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	records := make([]*outboxRecord, 0, len(o.pending))
+	for _, record := range o.pending {
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// Close closes the outbox file.
+func (o *Outbox) Close() error {
+	if o == nil {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.file.Close()
+}
+
+// append writes record to the outbox file and syncs it to disk.
+func (o *Outbox) append(record *outboxRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal outbox record: %w", err)
+	}
+
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write outbox record: %w", err)
+	}
+
+	if err := o.file.Sync(); err != nil {
+		return fmt.Errorf("sync outbox: %w", err)
+	}
+
+	return nil
+}
+
+// resubmitPending submits the proofs left pending by a previous run right away, then
+// every outboxResubmitInterval the ones whose submission failed since, until ctx is done.
+// Proofs of blocks more than maxAgeSlots slots old are given up on.
+// The outbox file is compacted along the way.
+func resubmitPending(ctx context.Context, outbox *Outbox, submitter *Submitter, clock *lazySlotClock, maxAgeSlots uint64) {
+	if outbox == nil {
+		return
+	}
+
+	ticker := time.NewTicker(outboxResubmitInterval)
+	defer ticker.Stop()
+
+	for {
+		resubmitPendingOnce(ctx, outbox, submitter, clock, maxAgeSlots)
+
+		if err := outbox.compact(); err != nil {
+			logger.Error("Failed to compact outbox", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resubmitPendingOnce submits the pending proofs once, leaving alone the ones
+// added less than outboxResubmitInterval ago, which are still being submitted.
+func resubmitPendingOnce(ctx context.Context, outbox *Outbox, submitter *Submitter, clock *lazySlotClock, maxAgeSlots uint64) {
+	var pending []*outboxRecord
+	for _, record := range outbox.Pending() {
+		if time.Since(record.addedAt) >= outboxResubmitInterval {
+			pending = append(pending, record)
+		}
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	slotClock, err := clock.get(ctx)
+	if err != nil {
+		logger.Error("Failed to resubmit pending proofs", "count", len(pending), "error", err)
+		return
+	}
+	currentSlot := slotClock.CurrentSlot()
+
+	logger.Info("Resubmitting pending proofs", "count", len(pending), "currentSlot", currentSlot)
+
+	var group errgroup.Group
+	group.SetLimit(outboxResubmitConcurrency)
+
+	for _, record := range pending {
+		if record.Slot+Slot(maxAgeSlots) < currentSlot {
+			logger.Info("Giving up on stale pending proof", "id", record.ID, "slot", record.Slot)
+			outboxResubmissions.WithLabelValues(outboxResultExpired).Inc()
+
+			if err := outbox.Done(record.ID); err != nil {
+				logger.Error("Failed to update outbox", "id", record.ID, "error", err)
+			}
+			continue
+		}
+
+		group.Go(func() error {
+			if err := submitter.Submit(ctx, record.Proof); err != nil {
+				logger.Warn("Failed to resubmit pending proof", "id", record.ID, "slot", record.Slot, "error", err)
+				outboxResubmissions.WithLabelValues(submissionResultFailure).Inc()
+				return nil
+			}
+
+			outboxResubmissions.WithLabelValues(submissionResultSuccess).Inc()
+			if err := outbox.Done(record.ID); err != nil {
+				logger.Error("Failed to update outbox", "id", record.ID, "error", err)
+			}
+
+			return nil
+		})
+	}
+	group.Wait()
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingTarget records the proofs submitted to it, failing them if err is set.
+type recordingTarget struct {
+	err error
+
+	mu        sync.Mutex
+	submitted []*SignedExecutionProof
+}
+
+func (t *recordingTarget) URL() string { return "test://target" }
+
+func (t *recordingTarget) CheckHealth(context.Context) error { return nil }
+
+func (t *recordingTarget) SubmitSignedExecutionProof(_ context.Context, proof *SignedExecutionProof) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.submitted = append(t.submitted, proof)
+	return t.err
+}
+
+// testSignedProof returns a signed proof whose proof data is data.
+func testSignedProof(data []byte) *SignedExecutionProof {
+	proof := testExecutionProof()
+	proof.ProofData = data
+
+	return &SignedExecutionProof{Message: proof, ValidatorIndex: 42, Signature: testSignature}
+}
+
+func TestOutboxReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	outbox, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submittedID, err := outbox.Add(1, testSignedProof([]byte{0x01}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	large := testSignedProof(bytes.Repeat([]byte{0x02}, maxProofSize))
+	pendingID, err := outbox.Add(2, large)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := outbox.Done(submittedID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := outbox.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A record whose line is longer than any line length limit would allow, then one cut short by a crash
+	oversized := &outboxRecord{Kind: outboxRecordPending, ID: "0x44", Slot: 3, Proof: testSignedProof(bytes.Repeat([]byte{0x04}, 3*maxProofSize))}
+
+	path := filepath.Join(dir, outboxFileName)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewEncoder(file).Encode(oversized); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(`{"kind":"pending","id":"0x33`); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if got := readOutboxFile(t, dir); len(got) != 5 {
+		t.Fatalf("records before reopening: got %d, want 5", len(got))
+	}
+
+	outbox, err = OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+
+	pending := outbox.Pending()
+	slices.SortFunc(pending, func(a, b *outboxRecord) int { return int(a.Slot) - int(b.Slot) })
+
+	if len(pending) != 2 || pending[0].ID != pendingID || pending[0].Slot != 2 || pending[1].ID != oversized.ID || pending[1].Slot != 3 {
+		t.Fatalf("pending: got %v, want %s at slot 2 and %s at slot 3", pending, pendingID, oversized.ID)
+	}
+
+	for i, want := range []*SignedExecutionProof{large, oversized.Proof} {
+		if !pending[i].addedAt.IsZero() {
+			t.Errorf("loaded record %s added at %s, want zero", pending[i].ID, pending[i].addedAt)
+		}
+
+		if !bytes.Equal(pending[i].Proof.Message.ProofData, want.Message.ProofData) {
+			t.Errorf("loaded record %s: proof data differs", pending[i].ID)
+		}
+	}
+
+	// Opening compacted the file to the pending records
+	var ids []string
+	for _, record := range readOutboxFile(t, dir) {
+		if record.Kind != outboxRecordPending {
+			t.Errorf("record %s after reopening: got kind %s, want %s", record.ID, record.Kind, outboxRecordPending)
+		}
+		ids = append(ids, record.ID)
+	}
+	slices.Sort(ids)
+
+	if want := []string{pendingID, oversized.ID}; !slices.Equal(ids, slices.Sorted(slices.Values(want))) {
+		t.Fatalf("records after reopening: got %v, want %v", ids, want)
+	}
+}
+
+func TestOutboxCompact(t *testing.T) {
+	dir := t.TempDir()
+
+	outbox, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+
+	var ids []string
+	for slot := range Slot(3) {
+		id, err := outbox.Add(slot, testSignedProof([]byte{byte(slot) + 1}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids[:2] {
+		if err := outbox.Done(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Marking an unknown or already submitted proof as done records nothing
+	if err := outbox.Done(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Below the threshold, the file is left alone
+	if err := outbox.compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readOutboxFile(t, dir); len(got) != 5 {
+		t.Fatalf("records below the threshold: got %d, want 5", len(got))
+	}
+
+	outbox.done = outboxCompactThreshold
+	if err := outbox.compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readOutboxFile(t, dir)
+	if len(records) != 1 || records[0].ID != ids[2] {
+		t.Fatalf("records after compaction: got %v, want the pending %s only", records, ids[2])
+	}
+
+	// Records are appended to the compacted file
+	if err := outbox.Done(ids[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	records = readOutboxFile(t, dir)
+	if len(records) != 2 || records[1].Kind != outboxRecordDone || records[1].ID != ids[2] {
+		t.Fatalf("records after compaction and done: got %v, want %s pending then done", records, ids[2])
+	}
+
+	if outbox.done != 1 {
+		t.Errorf("done records since compaction: got %d, want 1", outbox.done)
+	}
+}
+
+func TestResubmitPendingOnce(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		targetErr     error
+		wantSubmitted []Slot
+		wantPending   []Slot
+	}{
+		{
+			name:          "submitted",
+			wantSubmitted: []Slot{90},
+			wantPending:   []Slot{99},
+		},
+		{
+			name:          "failed",
+			targetErr:     errors.New("unavailable"),
+			wantSubmitted: []Slot{90},
+			wantPending:   []Slot{90, 99},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			outbox, err := OpenOutbox(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Proofs left pending by a previous run, one of them too old to resubmit
+			for _, slot := range []Slot{10, 90} {
+				if _, err := outbox.Add(slot, testSignedProof([]byte{byte(slot)})); err != nil {
+					t.Fatal(err)
+				}
+			}
+			outbox.Close()
+
+			outbox, err = OpenOutbox(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer outbox.Close()
+
+			// A proof still being submitted by its own worker
+			if _, err := outbox.Add(99, testSignedProof([]byte{99})); err != nil {
+				t.Fatal(err)
+			}
+
+			target := &recordingTarget{err: tc.targetErr}
+			submitter, err := NewSubmitter([]proofTarget{target}, submissionPolicyAll)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The current slot is 100
+			slotDuration := 12 * time.Second
+			clock := &lazySlotClock{clock: NewSlotClock(time.Now().Add(-100*slotDuration-slotDuration/2), slotDuration, 32)}
+
+			resubmitPendingOnce(context.Background(), outbox, submitter, clock, 32)
+
+			var submitted []Slot
+			for _, proof := range target.submitted {
+				submitted = append(submitted, Slot(proof.Message.ProofData[0]))
+			}
+
+			if !slices.Equal(submitted, tc.wantSubmitted) {
+				t.Errorf("submitted slots: got %v, want %v", submitted, tc.wantSubmitted)
+			}
+
+			var pending []Slot
+			for _, record := range outbox.Pending() {
+				pending = append(pending, record.Slot)
+			}
+			slices.Sort(pending)
+
+			if !slices.Equal(pending, tc.wantPending) {
+				t.Errorf("pending slots: got %v, want %v", pending, tc.wantPending)
+			}
+		})
+	}
+}
+
+// readOutboxFile returns the records of the outbox file of dir, the last one
+// possibly incomplete with only its kind set.
+func readOutboxFile(t *testing.T, dir string) []*outboxRecord {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, outboxFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var records []*outboxRecord
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := new(outboxRecord)
+		if err := json.Unmarshal([]byte(line), record); err != nil {
+			record.Kind = "invalid"
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Queue overflow policies, applied when a block event arrives while the queue is full.
+const (
+	overflowDropOldest = "drop-oldest"
+	overflowDropNewest = "drop-newest"
+	overflowBlock      = "block"
+)
+
+// Reasons a block event is dropped without its proofs being submitted.
+const (
+	dropReasonOverflow = "overflow"
+	dropReasonExpired  = "expired"
+	dropReasonShutdown = "shutdown"
+)
+
+// Pipeline processes block events concurrently with a bounded pool of workers
+// fed by a bounded queue.
+type Pipeline struct {
+	prover        *Prover
+	health        *Health
+	clock         *lazySlotClock
+	workers       int
+	overflow      string
+	deadlineSlots uint64
+
+	queue      chan BlockEventData
+	closeQueue sync.Once
+	stop       chan struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	mu        sync.Mutex
+	processed int
+	dropped   map[string]int
+}
+
+// NewPipeline creates a pipeline of workers handling blocks with prover.
+// overflow is one of drop-oldest, drop-newest or block.
+// A block is abandoned once deadlineSlots slots have started after its own, 0 disables the deadline.
+func NewPipeline(prover *Prover, health *Health, clock *lazySlotClock, workers int, queueDepth int, overflow string, deadlineSlots uint64) (*Pipeline, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("invalid number of workers %d: must be at least 1", workers)
+	}
+
+	if queueDepth < 1 {
+		return nil, fmt.Errorf("invalid queue depth %d: must be at least 1", queueDepth)
+	}
+
+	switch overflow {
+	case overflowDropOldest, overflowDropNewest, overflowBlock:
+	default:
+		return nil, fmt.Errorf("unknown queue overflow policy %q (want %s, %s or %s)", overflow, overflowDropOldest, overflowDropNewest, overflowBlock)
+	}
+
+	return &Pipeline{
+		prover:        prover,
+		health:        health,
+		clock:         clock,
+		workers:       workers,
+		overflow:      overflow,
+		deadlineSlots: deadlineSlots,
+		queue:         make(chan BlockEventData, queueDepth),
+		stop:          make(chan struct{}),
+		dropped:       make(map[string]int),
+	}, nil
+}
+
+// Start starts the workers. They stop when ctx is done or after Shutdown.
+func (p *Pipeline) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	for range p.workers {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.work(ctx)
+		}()
+	}
+}
+
+// Enqueue queues a block event, applying the overflow policy if the queue is full.
+// With the block policy, it waits until there is room or ctx is done.
+func (p *Pipeline) Enqueue(ctx context.Context, event BlockEventData) {
+	defer func() { queueDepth.Set(float64(len(p.queue))) }()
+
+	switch p.overflow {
+	case overflowBlock:
+		select {
+		case p.queue <- event:
+		case <-ctx.Done():
+			p.drop(event, dropReasonShutdown)
+		}
+
+	case overflowDropNewest:
+		select {
+		case p.queue <- event:
+		default:
+			p.drop(event, dropReasonOverflow)
+		}
+
+	case overflowDropOldest:
+		for {
+			select {
+			case p.queue <- event:
+				return
+			default:
+			}
+
+			// Make room, unless a worker just did
+			select {
+			case oldest := <-p.queue:
+				p.drop(oldest, dropReasonOverflow)
+			default:
+			}
+		}
+	}
+}
+
+// Shutdown lets the workers handle the queued and in-flight block events until ctx is done,
+// then cancels the in-flight ones and drops the ones still queued.
+// Enqueue must not be called anymore.
+func (p *Pipeline) Shutdown(ctx context.Context) {
+	p.closeQueue.Do(func() { close(p.queue) })
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Warn("Grace period expired, cancelling in-flight blocks", "queued", len(p.queue))
+		close(p.stop)
+		p.cancel()
+		<-done
+	}
+
+	// Left over when the grace period expired or the workers were cancelled
+	for event := range p.queue {
+		p.drop(event, dropReasonShutdown)
+	}
+	queueDepth.Set(0)
+
+	p.cancel()
+}
+
+// Drain waits for every queued block event to be handled, then stops the workers.
+// Enqueue must not be called anymore.
+func (p *Pipeline) Drain() {
+	p.closeQueue.Do(func() { close(p.queue) })
+	p.wg.Wait()
+	p.cancel()
+}
+
+// Summary returns the number of blocks processed and dropped by reason so far.
+func (p *Pipeline) Summary() (int, map[string]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.processed, maps.Clone(p.dropped)
+}
+
+// work handles queued block events until the queue is closed and empty, ctx is done
+// or the shutdown grace period expires.
+func (p *Pipeline) work(ctx context.Context) {
+	for {
+		// Check stop first, so queued events are left to Shutdown
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-p.stop:
+			return
+
+		case event, ok := <-p.queue:
+			if !ok {
+				return
+			}
+			queueDepth.Set(float64(len(p.queue)))
+			p.process(ctx, event)
+		}
+	}
+}
+
+// process handles a single block event within its slot deadline.
+func (p *Pipeline) process(ctx context.Context, event BlockEventData) {
+	deadline, ok := p.deadline(ctx, event)
+	if ok {
+		if time.Now().After(deadline) {
+			p.drop(event, dropReasonExpired)
+			return
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	if err := p.prover.handleBlockGossip(ctx, event); err != nil {
+		switch {
+		case ok && errors.Is(err, context.DeadlineExceeded) && !time.Now().Before(deadline):
+			p.drop(event, dropReasonExpired)
+		case errors.Is(err, context.Canceled):
+			p.drop(event, dropReasonShutdown)
+		default:
+			logger.Error("Failed to handle block gossip", "blockRoot", fmt.Sprintf("%#x", event.Block), "slot", event.Slot, "error", err)
+		}
+
+		return
+	}
+
+	p.mu.Lock()
+	p.processed++
+	p.mu.Unlock()
+
+	p.health.RecordProcessed(event.Slot)
+}
+
+// deadline returns the time after which the proofs of the block announced by event are no longer useful.
+// It returns false if deadlines are disabled, the slot clock is not available yet, or the event
+// was backfilled: replayed blocks are already older than the deadline, and would all be dropped.
+func (p *Pipeline) deadline(ctx context.Context, event BlockEventData) (time.Time, bool) {
+	if p.deadlineSlots == 0 || event.backfilled {
+		return time.Time{}, false
+	}
+
+	clock, err := p.clock.get(ctx)
+	if err != nil {
+		logger.Warn("Slot clock unavailable, processing block without deadline", "slot", event.Slot, "error", err)
+		return time.Time{}, false
+	}
+
+	return clock.SlotStart(event.Slot + Slot(p.deadlineSlots)), true
+}
+
+// drop records a block event abandoned without its proofs being submitted.
+func (p *Pipeline) drop(event BlockEventData, reason string) {
+	p.mu.Lock()
+	p.dropped[reason]++
+	p.mu.Unlock()
+
+	blocksDropped.WithLabelValues(reason).Inc()
+	logger.Warn("Dropped block", "blockRoot", fmt.Sprintf("%#x", event.Block), "slot", event.Slot, "reason", reason)
+}
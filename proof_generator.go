@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dummyProofGeneratorName  = "dummy"
+	randomProofGeneratorName = "random"
+)
+
+// ProofRequest holds everything a ProofGenerator may use to produce proof data.
+type ProofRequest struct {
+	Block                   *SignedBlindedBeaconBlock
+	ProofType               ProofType
+	NewPayloadRequestHeader *NewPayloadRequestHeader
+	NewPayloadRequestRoot   [32]byte
+}
+
+// ProofGenerator produces the proof_data of an execution proof.
+type ProofGenerator interface {
+	GenerateProofData(ctx context.Context, req *ProofRequest) ([]byte, error)
+}
+
+// dummyProofGenerator produces [0xFF, proofType, blockHash[0:4]].
+type dummyProofGenerator struct{}
+
+// GenerateProofData implements ProofGenerator.
+func (dummyProofGenerator) GenerateProofData(_ context.Context, req *ProofRequest) ([]byte, error) {
+	blockHash := req.Block.Message.Body.ExecutionPayloadHeader.BlockHash
+	if len(blockHash) < 4 {
+		return nil, fmt.Errorf("invalid block hash length: %d", len(blockHash))
+	}
+
+	return []byte{
+		0xFF,
+		byte(req.ProofType),
+		blockHash[0],
+		blockHash[1],
+		blockHash[2],
+		blockHash[3],
+	}, nil
+}
+
+// randomProofGenerator produces fixed-size random payloads.
+type randomProofGenerator struct {
+	size int
+}
+
+// GenerateProofData implements ProofGenerator.
+func (g *randomProofGenerator) GenerateProofData(_ context.Context, _ *ProofRequest) ([]byte, error) {
+	proofData := make([]byte, g.size)
+	if _, err := rand.Read(proofData); err != nil {
+		return nil, fmt.Errorf("read random: %w", err)
+	}
+
+	return proofData, nil
+}
+
+// newProofGenerator creates the proof generator backend with the given name.
+func newProofGenerator(name string, cfg Config) (ProofGenerator, error) {
+	switch name {
+	case dummyProofGeneratorName:
+		return dummyProofGenerator{}, nil
+	case randomProofGeneratorName:
+		if cfg.RandomProofSize <= 0 {
+			return nil, fmt.Errorf("random proof size must be positive, got %d", cfg.RandomProofSize)
+		}
+		return &randomProofGenerator{size: cfg.RandomProofSize}, nil
+	case execProofGeneratorName:
+		return newExecProofGenerator(cfg.ExecProofCommand, time.Duration(cfg.ExecProofTimeoutMs)*time.Millisecond, cfg.ExecProofConcurrency)
+	default:
+		return nil, fmt.Errorf("unknown proof generator %q", name)
+	}
+}
+
+// newProofGenerators creates one proof generator per proof type from a spec.
+// The spec is a comma-separated list of entries, either a bare backend name
+// used for every proof type, or "<proof type>=<backend>" to override a single
+// proof type, e.g. "dummy,1=random".
+func newProofGenerators(spec string, proofsPerBlock int, cfg Config) ([]ProofGenerator, error) {
+	if proofsPerBlock < 0 || proofsPerBlock > proofTypeCount {
+		return nil, fmt.Errorf("%d proofs per block out of range, only %d proof types", proofsPerBlock, proofTypeCount)
+	}
+
+	defaultName := dummyProofGeneratorName
+	names := make(map[ProofType]string)
+
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		proofTypeStr, name, ok := strings.Cut(entry, "=")
+		if !ok {
+			defaultName = entry
+			continue
+		}
+
+		proofType, err := strconv.ParseUint(strings.TrimSpace(proofTypeStr), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("parse proof type in %q: %w", entry, err)
+		}
+
+		if int(proofType) >= proofsPerBlock {
+			return nil, fmt.Errorf("proof type %d out of range, only %d proofs per block", proofType, proofsPerBlock)
+		}
+
+		names[ProofType(proofType)] = strings.TrimSpace(name)
+	}
+
+	generators := make([]ProofGenerator, proofsPerBlock)
+	for proofType := range ProofType(proofsPerBlock) {
+		name, ok := names[proofType]
+		if !ok {
+			name = defaultName
+		}
+
+		generator, err := newProofGenerator(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("proof type %d: %w", proofType, err)
+		}
+
+		generators[proofType] = generator
+	}
+
+	return generators, nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Outcomes of proving a slot.
+const (
+	slotResultProved   = "proved"
+	slotResultEmpty    = "empty"
+	slotResultWithheld = "withheld"
+	slotResultFailed   = "failed"
+)
+
+// maxProveSlots bounds the number of slots proved at once, as their results are kept for the summary.
+const maxProveSlots = 1 << 16
+
+// slotResult is the outcome of proving a slot.
+type slotResult struct {
+	result   string
+	proofs   int
+	duration time.Duration
+	err      error
+}
+
+// proveMain runs the prove command: prove the blocks of a range of slots, then exit.
+func proveMain(args []string) error {
+	var cfg Config
+	var from, to uint64
+
+	fs := flag.NewFlagSet(proveCommand, flag.ExitOnError)
+	cfg.registerFlags(fs)
+	fs.Uint64Var(&from, "from", 0, "First slot to prove")
+	fs.Uint64Var(&to, "to", 0, "Last slot to prove, included")
+	fs.Parse(args)
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if !set["from"] || !set["to"] || from > to {
+		logger.Error("Invalid slot range, -from and -to are required and -from must not be after -to", "from", from, "to", to)
+		return errors.New("invalid slot range")
+	}
+
+	if to-from >= maxProveSlots {
+		logger.Error("Slot range too large, split it", "from", from, "to", to, "maxSlots", maxProveSlots)
+		return errors.New("invalid slot range")
+	}
+
+	if fs.NArg() > 0 {
+		logger.Error("Unexpected arguments", "args", fs.Args())
+		return errors.New("unexpected arguments")
+	}
+
+	return prove(cfg, Slot(from), Slot(to))
+}
+
+// prove generates and submits the proofs of the blocks from slot from to slot to included,
+// cfg.Workers slots at a time. Slots the sources confirm have no block are skipped, and an
+// error is returned if any slot failed or to is past the head.
+func prove(cfg Config, from, to Slot) error {
+	c, err := newComponents(cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// The first signal stops proving, in-flight slots failing
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("Proving slots",
+		"from", from,
+		"to", to,
+		"sources", c.sources.URLs(),
+		"targets", c.submitter.URLs(),
+		"submissionPolicy", cfg.SubmissionPolicy,
+		"signer", c.signerDescription,
+		"proofsPerBlock", cfg.ProofsPerBlock,
+		"proofGenerator", cfg.ProofGenerator,
+		"faultInjection", cfg.FaultInjection,
+		"scenario", cfg.Scenario,
+		"workers", cfg.Workers,
+	)
+
+	// Slots past the head have no block yet, they would pass for empty ones
+	head, err := c.sources.headSlot(ctx)
+	if err != nil {
+		logger.Error("Failed to get head slot", "error", err)
+		return fmt.Errorf("get head slot: %w", err)
+	}
+
+	if to > head {
+		logger.Error("Slot range past the head", "to", to, "headSlot", head)
+		return fmt.Errorf("slot %d is past the head slot %d", to, head)
+	}
+
+	results := make([]slotResult, to-from+1)
+
+	var group errgroup.Group
+	group.SetLimit(max(cfg.Workers, 1))
+
+	for i := range results {
+		group.Go(func() error {
+			results[i] = proveSlot(ctx, c.prover, c.sources, from+Slot(i))
+			return nil
+		})
+	}
+
+	group.Wait()
+
+	// Summarize slots in order
+	counts := make(map[string]int)
+	for i, result := range results {
+		slot := from + Slot(i)
+		counts[result.result]++
+
+		if result.err != nil {
+			logger.Error("Slot summary", "slot", slot, "result", result.result, "duration", result.duration, "error", result.err)
+			continue
+		}
+
+		logger.Info("Slot summary", "slot", slot, "result", result.result, "proofs", result.proofs, "duration", result.duration)
+	}
+
+	logger.Info("Prove summary",
+		"slots", len(results),
+		"proved", counts[slotResultProved],
+		"empty", counts[slotResultEmpty],
+		"withheld", counts[slotResultWithheld],
+		"failed", counts[slotResultFailed],
+	)
+
+	if failed := counts[slotResultFailed]; failed > 0 {
+		return fmt.Errorf("%d of %d slots failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// proveSlot proves the block of slot with prover and reports the outcome.
+// A missing block counts as empty only if sources confirm the slot has none.
+func proveSlot(ctx context.Context, prover *Prover, sources BlockSource, slot Slot) slotResult {
+	start := time.Now()
+
+	plan, err := prover.handleSlot(ctx, slot)
+	if errors.Is(err, errBlockNotFound) {
+		err = checkSlotEmpty(ctx, sources, slot, err)
+		if err == nil {
+			return slotResult{result: slotResultEmpty, duration: time.Since(start)}
+		}
+	}
+
+	duration := time.Since(start)
+
+	switch {
+	case err != nil:
+		return slotResult{result: slotResultFailed, duration: duration, err: err}
+	case plan.withhold:
+		return slotResult{result: slotResultWithheld, duration: duration}
+	default:
+		return slotResult{result: slotResultProved, proofs: len(plan.proofTypes), duration: duration}
+	}
+}
+
+// checkSlotEmpty returns nil if sources confirm slot has no block, or notFound,
+// the error fetching its block, with the reason the slot is not empty otherwise.
+func checkSlotEmpty(ctx context.Context, sources BlockSource, slot Slot, notFound error) error {
+	empty, err := sources.slotEmpty(ctx, slot)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%w, and failed to check the slot is empty: %w", notFound, err)
+	case !empty:
+		return fmt.Errorf("%w, though the slot has a block", notFound)
+	}
+
+	return nil
+}
@@ -2,43 +2,90 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand/v2"
+	"strconv"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	// blockNotFoundMinBackoff and blockNotFoundMaxBackoff bound the delay between
+	// fetches of a block announced before being served over HTTP.
+	blockNotFoundMinBackoff = 250 * time.Millisecond
+	blockNotFoundMaxBackoff = 2 * time.Second
+
+	// blockNotFoundMaxRetries is how many times a block not found is fetched again before giving up.
+	blockNotFoundMaxRetries = 8
+)
+
+// errBlockRootMismatch is returned when a fetched block does not hash to the announced block root.
+var errBlockRootMismatch = errors.New("block root mismatch")
+
 // Prover handles proof generation and submission.
 type Prover struct {
-	source           *BeaconClient
-	target           *BeaconClient
-	validatorClient  *ValidatorClient
+	sources          BlockSource
+	submitter        *Submitter
+	signer           Signer
+	generators       []ProofGenerator
+	outbox           *Outbox
+	faults           *FaultInjector
+	scenario         *Scenario
 	proofsPerBlock   int
 	proofDelay       time.Duration
 	proofDelayJitter time.Duration
+	retryBudget      int
 }
 
 // NewProver creates a new Prover instance.
-func NewProver(source *BeaconClient, target *BeaconClient, validatorClient *ValidatorClient, proofsPerBlock int, proofDelay time.Duration, proofDelayJitter time.Duration) *Prover {
+// generators holds one ProofGenerator per proof type, outbox (nil to disable) records
+// signed proofs until they are submitted, faults (nil to disable) corrupts some of
+// them on purpose, scenario (nil to disable) overrides the proofs of some slots,
+// and retryBudget bounds the number of request retries made for each block.
+func NewProver(sources BlockSource, submitter *Submitter, signer Signer, generators []ProofGenerator, outbox *Outbox, faults *FaultInjector, scenario *Scenario, proofDelay time.Duration, proofDelayJitter time.Duration, retryBudget int) *Prover {
 	return &Prover{
-		source:           source,
-		target:           target,
-		validatorClient:  validatorClient,
-		proofsPerBlock:   proofsPerBlock,
+		sources:          sources,
+		submitter:        submitter,
+		signer:           signer,
+		generators:       generators,
+		outbox:           outbox,
+		faults:           faults,
+		scenario:         scenario,
+		proofsPerBlock:   len(generators),
 		proofDelay:       proofDelay,
 		proofDelayJitter: proofDelayJitter,
+		retryBudget:      retryBudget,
 	}
 }
 
 // handleBlockGossip processes a block gossip event by fetching the block and submitting proofs.
 func (p *Prover) handleBlockGossip(ctx context.Context, event BlockEventData) error {
-	signedBlindedBeaconBlock, err := p.source.GetSignedBlindedBeaconBlock(ctx, fmt.Sprintf("%d", event.Slot))
+	inFlightBlocks.Inc()
+	defer inFlightBlocks.Dec()
+
+	plan, err := p.planProofs(ctx, event.Slot)
 	if err != nil {
+		return fmt.Errorf("plan proofs: %w", err)
+	}
+
+	if plan.withhold {
+		logger.Info("Withholding proofs", "blockRoot", fmt.Sprintf("%#x", event.Block), "slot", event.Slot)
+		return nil
+	}
+
+	ctx = withRetryBudget(ctx, p.retryBudget)
+
+	fetchStart := time.Now()
+	signedBlindedBeaconBlock, err := p.fetchBlock(ctx, event.Block)
+	if err != nil {
+		failures.WithLabelValues(stageFetch, "").Inc()
 		return fmt.Errorf("get signed blinded beacon block: %w", err)
 	}
+	blockFetchDuration.Observe(time.Since(fetchStart).Seconds())
 
-	if err := p.generateAndSubmitDummyProofs(ctx, signedBlindedBeaconBlock); err != nil {
+	if err := p.generateAndSubmitDummyProofs(ctx, signedBlindedBeaconBlock, plan, event.receivedAt); err != nil {
 		return fmt.Errorf("generate and submit dummy proofs: %w", err)
 	}
 
@@ -46,26 +93,129 @@ func (p *Prover) handleBlockGossip(ctx context.Context, event BlockEventData) er
 		"Submitted dummy proofs",
 		"blockRoot", fmt.Sprintf("%#x", event.Block),
 		"slot", event.Slot,
-		"count", p.proofsPerBlock,
+		"count", len(plan.proofTypes),
 	)
 
 	return nil
 }
 
-// generateAndSubmitDummyProofs generates and submits dummy proofs for a block.
-func (p *Prover) generateAndSubmitDummyProofs(ctx context.Context, block *SignedBlindedBeaconBlock) error {
+// handleSlot fetches the block of slot, then generates and submits its proofs as planned.
+// The block not found error is returned for slots without a block.
+func (p *Prover) handleSlot(ctx context.Context, slot Slot) (*proofPlan, error) {
+	plan, err := p.planProofs(ctx, slot)
+	if err != nil {
+		return nil, fmt.Errorf("plan proofs: %w", err)
+	}
+
+	if plan.withhold {
+		return plan, nil
+	}
+
+	ctx = withRetryBudget(ctx, p.retryBudget)
+
+	signedBlindedBeaconBlock, err := p.sources.GetSignedBlindedBeaconBlock(ctx, strconv.FormatUint(uint64(slot), 10))
+	if err != nil {
+		if !errors.Is(err, errBlockNotFound) {
+			failures.WithLabelValues(stageFetch, "").Inc()
+		}
+		return nil, fmt.Errorf("get signed blinded beacon block: %w", err)
+	}
+
+	if signedBlindedBeaconBlock.Message == nil || signedBlindedBeaconBlock.Message.Slot != slot {
+		failures.WithLabelValues(stageFetch, "").Inc()
+		return nil, fmt.Errorf("source served another block than the one of slot %d", slot)
+	}
+
+	if err := p.generateAndSubmitDummyProofs(ctx, signedBlindedBeaconBlock, plan, time.Time{}); err != nil {
+		return nil, fmt.Errorf("generate and submit dummy proofs: %w", err)
+	}
+
+	return plan, nil
+}
+
+// planProofs returns what to do with the proofs of the block at slot: the
+// prover's configuration, overridden by the scenario if any.
+func (p *Prover) planProofs(ctx context.Context, slot Slot) (*proofPlan, error) {
+	plan := &proofPlan{
+		proofTypes: make([]ProofType, 0, p.proofsPerBlock),
+		delay:      p.proofDelay,
+		submitter:  p.submitter,
+		faults:     p.faults,
+	}
+
+	for proofType := range ProofType(p.proofsPerBlock) {
+		plan.proofTypes = append(plan.proofTypes, proofType)
+	}
+
+	if err := p.scenario.apply(ctx, slot, plan); err != nil {
+		return nil, fmt.Errorf("scenario: %w", err)
+	}
+
+	return plan, nil
+}
+
+// fetchBlock fetches the block with the given root and checks it hashes to that root.
+// Blocks are sometimes announced before being served, so a block not found is
+// fetched again with exponential backoff, up to blockNotFoundMaxRetries times.
+func (p *Prover) fetchBlock(ctx context.Context, root Root) (*SignedBlindedBeaconBlock, error) {
+	blockID := fmt.Sprintf("%#x", root)
+	bo := newBackoff(blockNotFoundMinBackoff, blockNotFoundMaxBackoff)
+
+	for retry := 0; ; retry++ {
+		block, err := p.sources.GetSignedBlindedBeaconBlock(ctx, blockID)
+		if err == nil {
+			return block, verifyBlockRoot(block, root)
+		}
+
+		if !errors.Is(err, errBlockNotFound) || retry == blockNotFoundMaxRetries {
+			return nil, err
+		}
+
+		delay := bo.next()
+		blockFetchRetries.Inc()
+		logger.Debug("Block not found yet, retrying", "blockRoot", blockID, "retryIn", delay)
+
+		if !sleep(ctx, delay) {
+			return nil, fmt.Errorf("%w (block not found after %d retries)", ctx.Err(), retry)
+		}
+	}
+}
+
+// verifyBlockRoot returns an error if block does not hash to root.
+func verifyBlockRoot(block *SignedBlindedBeaconBlock, root Root) error {
+	if block.Message == nil {
+		return errors.New("block has no message")
+	}
+
+	blockRoot, err := block.Message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("block root: %w", err)
+	}
+
+	if blockRoot != root {
+		return fmt.Errorf("%w: got %#x, want %#x", errBlockRootMismatch, blockRoot, root)
+	}
+
+	return nil
+}
+
+// generateAndSubmitDummyProofs generates and submits dummy proofs for a block, as planned.
+// receivedAt is when the block event was received, used to measure end-to-end latency.
+func (p *Prover) generateAndSubmitDummyProofs(ctx context.Context, block *SignedBlindedBeaconBlock, plan *proofPlan, receivedAt time.Time) error {
 	// Generate all proofs in parallel
 	var genGroup errgroup.Group
 
-	proofs := make([]*SignedExecutionProof, p.proofsPerBlock)
-	for proofType := range ProofType(p.proofsPerBlock) {
+	proofs := make([]*SignedExecutionProof, len(plan.proofTypes))
+	injected := make([][]string, len(plan.proofTypes))
+	for i, proofType := range plan.proofTypes {
 		genGroup.Go(func() error {
-			proof, err := p.generateProof(ctx, proofType, block)
+			proof, faults, err := p.generateProof(ctx, proofType, block, plan.faults)
 			if err != nil {
 				return fmt.Errorf("generate proof %d: %w", proofType, err)
 			}
 
-			proofs[proofType] = proof
+			proofs[i] = proof
+			injected[i] = faults
 			return nil
 		})
 	}
@@ -75,7 +225,7 @@ func (p *Prover) generateAndSubmitDummyProofs(ctx context.Context, block *Signed
 	}
 
 	// Simulate proof generation delay (wait once for all proofs)
-	delay := p.proofDelay
+	delay := plan.delay
 	if p.proofDelayJitter > 0 {
 		jitter := time.Duration(rand.Int64N(int64(2*p.proofDelayJitter)+1)) - p.proofDelayJitter
 		delay += jitter
@@ -94,12 +244,43 @@ func (p *Prover) generateAndSubmitDummyProofs(ctx context.Context, block *Signed
 	// Submit all proofs in parallel
 	var submitGroup errgroup.Group
 
-	for proofType, proof := range proofs {
+	for i, proof := range proofs {
+		proofType := plan.proofTypes[i]
 		submitGroup.Go(func() error {
-			if err := p.target.SubmitSignedExecutionProof(ctx, proof); err != nil {
+			if len(injected[i]) > 0 {
+				return submitFaultyProof(ctx, plan.submitter, block.Message.Slot, proof, injected[i])
+			}
+
+			// Record the proof first so that it is submitted on the next start if this run fails to
+			outboxID, err := p.outbox.Add(block.Message.Slot, proof)
+			if err != nil {
+				logger.Error("Failed to record proof in outbox", "slot", block.Message.Slot, "proofType", proofType, "error", err)
+			}
+
+			submitStart := time.Now()
+			if err := plan.submitter.Submit(ctx, proof); err != nil {
+				failures.WithLabelValues(stageSubmit, proofTypeLabel(proofType)).Inc()
 				return fmt.Errorf("submit proof %d: %w", proofType, err)
 			}
 
+			if err := p.outbox.Done(outboxID); err != nil {
+				logger.Error("Failed to mark proof as submitted in outbox", "slot", block.Message.Slot, "proofType", proofType, "error", err)
+			}
+
+			now := time.Now()
+			submitDuration.Observe(now.Sub(submitStart).Seconds())
+			if !receivedAt.IsZero() {
+				endToEndDuration.Observe(now.Sub(receivedAt).Seconds())
+			}
+			proofsSubmitted.WithLabelValues(proofTypeLabel(proofType)).Inc()
+
+			// Duplicates are expected to be ignored by beacon nodes, so their failures are not fatal
+			for duplicate := range plan.duplicates {
+				if err := plan.submitter.Submit(ctx, proof); err != nil {
+					logger.Warn("Failed to submit duplicate proof", "slot", block.Message.Slot, "proofType", proofType, "duplicate", duplicate+1, "error", err)
+				}
+			}
+
 			return nil
 		})
 	}
@@ -111,36 +292,54 @@ func (p *Prover) generateAndSubmitDummyProofs(ctx context.Context, block *Signed
 	return nil
 }
 
-// generateProof creates an execution proof and signs it using the validator client.
-func (p *Prover) generateProof(ctx context.Context, proofType ProofType, signedBlindedBeaconBlock *SignedBlindedBeaconBlock) (*SignedExecutionProof, error) {
-	beaconBlock := signedBlindedBeaconBlock.Message
-	beaconBlockBody := beaconBlock.Body
-	ExecutionPayloadHeader := beaconBlockBody.ExecutionPayloadHeader
+// submitFaultyProof submits proof, into which faults were injected, and records whether it was
+// rejected or accepted. Faulty proofs are expected to be rejected, so only a cancelled
+// submission is an error. They are neither recorded in the outbox nor duplicated.
+func submitFaultyProof(ctx context.Context, submitter *Submitter, slot Slot, proof *SignedExecutionProof, faults []string) error {
+	proofType := proof.Message.ProofType
 
-	// Dummy proof data format: [0xFF, proofID, blockHash[0], blockHash[1], blockHash[2], blockHash[3]]
-	blockHash := ExecutionPayloadHeader.BlockHash
+	err := submitter.Submit(ctx, proof)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("submit faulty proof %d: %w", proofType, ctxErr)
+	}
 
-	proofData := []byte{
-		0xFF,
-		byte(proofType),
-		blockHash[0],
-		blockHash[1],
-		blockHash[2],
-		blockHash[3],
+	result := faultResultRejected
+	if err == nil {
+		result = faultResultAccepted
+		logger.Warn("Faulty proof accepted", "slot", slot, "proofType", proofType, "faults", faults)
+	} else {
+		logger.Info("Faulty proof rejected", "slot", slot, "proofType", proofType, "faults", faults, "error", err)
 	}
 
-	newPayloadRequestHeader := &NewPayloadRequestHeader{
-		ExecutionPayloadHeader: ExecutionPayloadHeader,
-		VersionedHashes:        kzgCommitmentsToVersionedHashes(beaconBlockBody),
-		ParentBeaconBlockRoot:  beaconBlock.ParentRoot, // <-- We cheat here as we should use state.latest_block_header.parent_root
-		ExecutionRequests:      beaconBlockBody.ExecutionRequests,
+	for _, fault := range faults {
+		faultySubmissions.WithLabelValues(proofTypeLabel(proofType), fault, result).Inc()
 	}
 
-	newPayloadRequestRoot, err := newPayloadRequestHeader.HashTreeRoot()
+	return nil
+}
+
+// generateProof creates an execution proof with the proof type's generator and signs it.
+// faults (nil to disable) corrupts the proof on purpose, and the injected faults are also reported.
+func (p *Prover) generateProof(ctx context.Context, proofType ProofType, signedBlindedBeaconBlock *SignedBlindedBeaconBlock, faults *FaultInjector) (*SignedExecutionProof, []string, error) {
+	beaconBlock := signedBlindedBeaconBlock.Message
+
+	newPayloadRequestHeader, newPayloadRequestRoot, err := buildNewPayloadRequestHeader(beaconBlock)
 	if err != nil {
-		return nil, fmt.Errorf("new payload request root: %w", err)
+		return nil, nil, err
 	}
 
+	proofData, err := p.generators[proofType].GenerateProofData(ctx, &ProofRequest{
+		Block:                   signedBlindedBeaconBlock,
+		ProofType:               proofType,
+		NewPayloadRequestHeader: newPayloadRequestHeader,
+		NewPayloadRequestRoot:   newPayloadRequestRoot,
+	})
+	if err != nil {
+		failures.WithLabelValues(stageGenerate, proofTypeLabel(proofType)).Inc()
+		return nil, nil, fmt.Errorf("generate proof data: %w", err)
+	}
+	proofsGenerated.WithLabelValues(proofTypeLabel(proofType)).Inc()
+
 	publicInput := &PublicInput{
 		NewPayloadRequestRoot: newPayloadRequestRoot[:],
 	}
@@ -151,11 +350,48 @@ func (p *Prover) generateProof(ctx context.Context, proofType ProofType, signedB
 		PublicInput: publicInput,
 	}
 
-	// Sign the proof using the validator client
-	signedProof, err := p.validatorClient.SignExecutionProof(ctx, executionProof)
+	injectedBefore, err := faults.injectBeforeSigning(beaconBlock.Slot, executionProof, newPayloadRequestHeader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("inject fault: %w", err)
+	}
+
+	// Sign the proof
+	signStart := time.Now()
+	signedProof, err := p.signer.SignExecutionProof(ctx, executionProof)
+	if err != nil {
+		failures.WithLabelValues(stageSign, proofTypeLabel(proofType)).Inc()
+		return nil, nil, fmt.Errorf("sign execution proof: %w", err)
+	}
+	signDuration.Observe(time.Since(signStart).Seconds())
+	proofsSigned.WithLabelValues(proofTypeLabel(proofType)).Inc()
+
+	injectedAfter, err := faults.injectAfterSigning(ctx, beaconBlock.Slot, proofType, signedProof, p.signer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("inject fault: %w", err)
+	}
+
+	return signedProof, append(injectedBefore, injectedAfter...), nil
+}
+
+// buildNewPayloadRequestHeader returns the NewPayloadRequestHeader of beaconBlock, which
+// proofs commit to, and its hash tree root.
+func buildNewPayloadRequestHeader(beaconBlock *BlindedBeaconBlock) (*NewPayloadRequestHeader, [32]byte, error) {
+	beaconBlockBody := beaconBlock.Body
+
+	// The spec uses state.latest_block_header.parent_root, which process_block_header
+	// sets to the block's own parent_root before the execution payload is processed,
+	// skipped slots or not. No state lookup is needed.
+	newPayloadRequestHeader := &NewPayloadRequestHeader{
+		ExecutionPayloadHeader: beaconBlockBody.ExecutionPayloadHeader,
+		VersionedHashes:        kzgCommitmentsToVersionedHashes(beaconBlockBody),
+		ParentBeaconBlockRoot:  beaconBlock.ParentRoot,
+		ExecutionRequests:      beaconBlockBody.ExecutionRequests,
+	}
+
+	newPayloadRequestRoot, err := newPayloadRequestHeader.HashTreeRoot()
 	if err != nil {
-		return nil, fmt.Errorf("sign execution proof: %w", err)
+		return nil, [32]byte{}, fmt.Errorf("new payload request root: %w", err)
 	}
 
-	return signedProof, nil
+	return newPayloadRequestHeader, newPayloadRequestRoot, nil
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	retryMinBackoff = 200 * time.Millisecond
+	retryMaxBackoff = 2 * time.Second
+)
+
+// Classes of failed HTTP requests, matched with errors.Is.
+var (
+	errNotFound    = errors.New("not found")
+	errBadRequest  = errors.New("bad request")
+	errRateLimited = errors.New("rate limited")
+	errServerError = errors.New("server error")
+	errTransport   = errors.New("transport error")
+)
+
+// httpStatusError is returned when a server answers with an unexpected status code.
+// It matches the class of the status code with errors.Is.
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *httpStatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return errRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return errServerError
+	case e.StatusCode >= http.StatusBadRequest:
+		return errBadRequest
+	default:
+		return nil
+	}
+}
+
+// transportError wraps an error of a request that got no HTTP answer.
+func transportError(err error) error {
+	return fmt.Errorf("%w: %w", errTransport, err)
+}
+
+// isRetryable reports whether a failed request may succeed if sent again:
+// rate limiting, server errors and transport errors.
+func isRetryable(err error) bool {
+	return errors.Is(err, errRateLimited) || errors.Is(err, errServerError) || errors.Is(err, errTransport)
+}
+
+// retryPolicy retries failed requests with exponential backoff and jitter.
+// It must only wrap idempotent requests, as a request that timed out may have been processed.
+type retryPolicy struct {
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+// newRetryPolicy creates a policy making up to maxAttempts attempts per request.
+func newRetryPolicy(maxAttempts int) retryPolicy {
+	return retryPolicy{
+		maxAttempts: max(maxAttempts, 1),
+		minBackoff:  retryMinBackoff,
+		maxBackoff:  retryMaxBackoff,
+	}
+}
+
+// do calls fn until it succeeds, fails with an error that is not retryable,
+// runs out of attempts or exhausts the retry budget of ctx, if any.
+// operation names the request in logs and metrics.
+func (p retryPolicy) do(ctx context.Context, operation string, fn func() error) error {
+	bo := newBackoff(p.minBackoff, p.maxBackoff)
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryable(err) || attempt >= p.maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		if !retryBudgetFromContext(ctx).take() {
+			retryBudgetExhausted.Inc()
+			return fmt.Errorf("%w (retry budget exhausted)", err)
+		}
+
+		delay := bo.next()
+		httpRetries.WithLabelValues(operation).Inc()
+		logger.Debug("Request failed, retrying", "operation", operation, "attempt", attempt, "retryIn", delay, "error", err)
+
+		if !sleep(ctx, delay) {
+			return err
+		}
+	}
+}
+
+// retryValue is retryPolicy.do for requests returning a value.
+func retryValue[T any](ctx context.Context, p retryPolicy, operation string, fn func() (T, error)) (T, error) {
+	var value T
+	err := p.do(ctx, operation, func() error {
+		var err error
+		value, err = fn()
+		return err
+	})
+
+	return value, err
+}
+
+// retryBudget bounds the number of retries shared by every request made for a block.
+type retryBudget struct {
+	remaining atomic.Int64
+}
+
+type retryBudgetKey struct{}
+
+// withRetryBudget returns a context allowing at most n retries across every request made with it.
+func withRetryBudget(ctx context.Context, n int) context.Context {
+	budget := new(retryBudget)
+	budget.remaining.Store(int64(n))
+
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// retryBudgetFromContext returns the retry budget of ctx, nil if there is none.
+func retryBudgetFromContext(ctx context.Context) *retryBudget {
+	budget, _ := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	return budget
+}
+
+// take consumes one retry, returning false if the budget is exhausted.
+// A nil budget is unlimited.
+func (b *retryBudget) take() bool {
+	if b == nil {
+		return true
+	}
+
+	return b.remaining.Add(-1) >= 0
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultScenarioRule names the behavior of slots no scenario rule matches, in logs.
+const defaultScenarioRule = "default"
+
+// proofPlan is what the prover does with the proofs of a block.
+type proofPlan struct {
+	// withhold skips generating and submitting the block's proofs.
+	withhold bool
+
+	// proofTypes are the proof types generated for the block.
+	proofTypes []ProofType
+
+	// delay is the simulated proof generation delay, before jitter.
+	delay time.Duration
+
+	// submitter submits the proofs, to every target or a subset of them.
+	submitter *Submitter
+
+	// faults corrupts proofs on purpose, nil to disable.
+	faults *FaultInjector
+
+	// duplicates is how many times each proof is submitted again once accepted.
+	duplicates int
+}
+
+// scenarioFile is the format of a -scenario file, in YAML or JSON.
+type scenarioFile struct {
+	Rules []*scenarioRule `yaml:"rules"`
+}
+
+// scenarioRule overrides the proof plan of the blocks of a slot, epoch or range of them.
+// Exactly one of Slot, Slots, Epoch and Epochs selects the blocks, ranges being inclusive
+// (e.g. "100-110"). Unset overrides keep the prover's configuration.
+type scenarioRule struct {
+	Name   string  `yaml:"name"`
+	Slot   *uint64 `yaml:"slot"`
+	Slots  string  `yaml:"slots"`
+	Epoch  *uint64 `yaml:"epoch"`
+	Epochs string  `yaml:"epochs"`
+
+	Withhold       bool     `yaml:"withhold"`
+	ProofsPerBlock *int     `yaml:"proofs_per_block"`
+	ProofTypes     []uint8  `yaml:"proof_types"`
+	DelayMs        *int     `yaml:"delay_ms"`
+	Targets        []string `yaml:"targets"`
+	FaultInjection *string  `yaml:"fault_injection"`
+	Duplicates     int      `yaml:"duplicates"`
+
+	// Resolved when the scenario is loaded
+	first, last uint64
+	byEpoch     bool
+	proofTypes  []ProofType
+	submitter   *Submitter
+	faults      *FaultInjector
+}
+
+// Scenario scripts the proofs of each block, for reproducible tests: the first
+// rule matching the slot of a block overrides how its proofs are produced and submitted.
+// A nil *Scenario never overrides anything.
+type Scenario struct {
+	rules []*scenarioRule
+	clock *lazySlotClock
+
+	mu      sync.Mutex
+	current string
+}
+
+// LoadScenario loads the scenario file at path. Rules are checked against the
+// number of proofs per block and the targets of submitter, and epochs are mapped to slots with clock.
+func LoadScenario(path string, proofsPerBlock int, submitter *Submitter, clock *lazySlotClock) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenario: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var file scenarioFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parse scenario: %w", err)
+	}
+
+	for i, rule := range file.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		if err := rule.resolve(proofsPerBlock, submitter); err != nil {
+			return nil, fmt.Errorf("%s: %w", rule.Name, err)
+		}
+	}
+
+	return &Scenario{
+		rules:   file.Rules,
+		clock:   clock,
+		current: defaultScenarioRule,
+	}, nil
+}
+
+// resolve validates the rule and prepares its selector and overrides.
+func (r *scenarioRule) resolve(proofsPerBlock int, submitter *Submitter) error {
+	selectors := 0
+	var err error
+
+	if r.Slot != nil {
+		selectors++
+		r.first, r.last = *r.Slot, *r.Slot
+	}
+	if r.Slots != "" {
+		selectors++
+		if r.first, r.last, err = parseRange(r.Slots); err != nil {
+			return fmt.Errorf("slots: %w", err)
+		}
+	}
+	if r.Epoch != nil {
+		selectors++
+		r.first, r.last, r.byEpoch = *r.Epoch, *r.Epoch, true
+	}
+	if r.Epochs != "" {
+		selectors++
+		if r.first, r.last, err = parseRange(r.Epochs); err != nil {
+			return fmt.Errorf("epochs: %w", err)
+		}
+		r.byEpoch = true
+	}
+
+	if selectors != 1 {
+		return errors.New("exactly one of slot, slots, epoch and epochs must be set")
+	}
+
+	if r.ProofsPerBlock != nil && r.ProofTypes != nil {
+		return errors.New("proofs_per_block and proof_types are mutually exclusive")
+	}
+
+	if r.ProofsPerBlock != nil {
+		if *r.ProofsPerBlock > proofTypeCount {
+			return fmt.Errorf("proofs_per_block %d out of range, only %d proof types", *r.ProofsPerBlock, proofTypeCount)
+		}
+
+		if *r.ProofsPerBlock < 0 || *r.ProofsPerBlock > proofsPerBlock {
+			return fmt.Errorf("proofs_per_block %d out of range, only %d proofs per block", *r.ProofsPerBlock, proofsPerBlock)
+		}
+
+		r.proofTypes = []ProofType{}
+		for proofType := range ProofType(*r.ProofsPerBlock) {
+			r.proofTypes = append(r.proofTypes, proofType)
+		}
+	}
+
+	if r.ProofTypes != nil {
+		r.proofTypes = []ProofType{}
+		for _, proofType := range r.ProofTypes {
+			if proofType >= proofTypeCount {
+				return fmt.Errorf("invalid proof type %d, only %d proof types", proofType, proofTypeCount)
+			}
+
+			if int(proofType) >= proofsPerBlock {
+				return fmt.Errorf("proof type %d out of range, only %d proofs per block", proofType, proofsPerBlock)
+			}
+
+			r.proofTypes = append(r.proofTypes, ProofType(proofType))
+		}
+	}
+
+	if r.DelayMs != nil && *r.DelayMs < 0 {
+		return fmt.Errorf("negative delay_ms %d", *r.DelayMs)
+	}
+
+	if r.Duplicates < 0 {
+		return fmt.Errorf("negative duplicates %d", r.Duplicates)
+	}
+
+	if r.Targets != nil {
+		if r.submitter, err = submitter.withTargets(r.Targets); err != nil {
+			return fmt.Errorf("targets: %w", err)
+		}
+	}
+
+	if r.FaultInjection != nil {
+		if r.faults, err = newFaultInjector(*r.FaultInjection, proofsPerBlock); err != nil {
+			return fmt.Errorf("fault_injection: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// parseRange parses an inclusive "<first>-<last>" range.
+func parseRange(s string) (uint64, uint64, error) {
+	firstStr, lastStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid range %q, want <first>-<last>", s)
+	}
+
+	first, err := strconv.ParseUint(strings.TrimSpace(firstStr), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse range start: %w", err)
+	}
+
+	last, err := strconv.ParseUint(strings.TrimSpace(lastStr), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse range end: %w", err)
+	}
+
+	if first > last {
+		return 0, 0, fmt.Errorf("invalid range %q, start after end", s)
+	}
+
+	return first, last, nil
+}
+
+// matches reports whether the rule applies to slot.
+func (r *scenarioRule) matches(ctx context.Context, slot Slot, clock *lazySlotClock) (bool, error) {
+	position := uint64(slot)
+	if r.byEpoch {
+		slotClock, err := clock.get(ctx)
+		if err != nil {
+			return false, fmt.Errorf("slot clock: %w", err)
+		}
+
+		position = slotClock.EpochOf(slot)
+	}
+
+	return position >= r.first && position <= r.last, nil
+}
+
+// apply overrides plan with the rule.
+func (r *scenarioRule) apply(plan *proofPlan) {
+	plan.withhold = r.Withhold
+
+	if r.proofTypes != nil {
+		plan.proofTypes = r.proofTypes
+	}
+
+	if r.DelayMs != nil {
+		plan.delay = time.Duration(*r.DelayMs) * time.Millisecond
+	}
+
+	if r.submitter != nil {
+		plan.submitter = r.submitter
+	}
+
+	if r.FaultInjection != nil {
+		plan.faults = r.faults
+	}
+
+	plan.duplicates = r.Duplicates
+}
+
+// apply overrides plan with the first rule matching slot, logging transitions between rules.
+func (s *Scenario) apply(ctx context.Context, slot Slot, plan *proofPlan) error {
+	if s == nil {
+		return nil
+	}
+
+	name := defaultScenarioRule
+	for _, rule := range s.rules {
+		ok, err := rule.matches(ctx, slot, s.clock)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rule.Name, err)
+		}
+
+		if ok {
+			rule.apply(plan)
+			name = rule.Name
+			break
+		}
+	}
+
+	s.mu.Lock()
+	previous := s.current
+	s.current = name
+	s.mu.Unlock()
+
+	if name != previous {
+		logger.Info("Scenario transition", "slot", slot, "from", previous, "to", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// namedTarget is a proof target only known by its URL.
+type namedTarget string
+
+func (t namedTarget) URL() string { return string(t) }
+
+func (t namedTarget) CheckHealth(context.Context) error { return nil }
+
+func (t namedTarget) SubmitSignedExecutionProof(context.Context, *SignedExecutionProof) error {
+	return nil
+}
+
+// loadTestScenario loads scenario, for 4 proofs per block, targets a and b and 32 slots per epoch.
+func loadTestScenario(t *testing.T, scenario string) (*Scenario, *Submitter, error) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	if err := os.WriteFile(path, []byte(scenario), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	submitter, err := NewSubmitter([]proofTarget{namedTarget("http://a"), namedTarget("http://b")}, submissionPolicyAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &lazySlotClock{clock: NewSlotClock(time.Unix(1606824023, 0), 12*time.Second, 32)}
+
+	loaded, err := LoadScenario(path, 4, submitter, clock)
+	return loaded, submitter, err
+}
+
+func TestLoadScenarioErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		scenario string
+		wantErr  string
+	}{
+		{name: "unknown field", scenario: "rules:\n  - slot: 1\n    withold: true\n", wantErr: "field withold not found"},
+		{name: "no selector", scenario: "rules:\n  - withhold: true\n", wantErr: "rule 1: exactly one of slot, slots, epoch and epochs must be set"},
+		{name: "slot and slots", scenario: "rules:\n  - slot: 1\n    slots: 1-2\n", wantErr: "exactly one of"},
+		{name: "slot and epoch", scenario: "rules:\n  - slot: 1\n    epoch: 1\n", wantErr: "exactly one of"},
+		{name: "epoch and epochs", scenario: "rules:\n  - name: epochs\n    epoch: 1\n    epochs: 1-2\n", wantErr: "epochs: exactly one of"},
+		{name: "range without end", scenario: "rules:\n  - slots: \"10\"\n", wantErr: `invalid range "10"`},
+		{name: "reversed range", scenario: "rules:\n  - epochs: 3-2\n", wantErr: "start after end"},
+		{name: "proofs per block and proof types", scenario: "rules:\n  - slot: 1\n    proofs_per_block: 1\n    proof_types: [0]\n", wantErr: "mutually exclusive"},
+		{name: "too many proofs per block", scenario: "rules:\n  - slot: 1\n    proofs_per_block: 5\n", wantErr: "proofs_per_block 5 out of range"},
+		{name: "more proofs per block than proof types", scenario: "rules:\n  - slot: 1\n    proofs_per_block: 9\n", wantErr: "only 8 proof types"},
+		{name: "unknown proof type", scenario: "rules:\n  - slot: 1\n    proof_types: [4]\n", wantErr: "proof type 4 out of range"},
+		{name: "invalid proof type", scenario: "rules:\n  - slot: 1\n    proof_types: [8]\n", wantErr: "invalid proof type 8"},
+		{name: "negative delay", scenario: "rules:\n  - slot: 1\n    delay_ms: -1\n", wantErr: "negative delay_ms"},
+		{name: "negative duplicates", scenario: "rules:\n  - slot: 1\n    duplicates: -1\n", wantErr: "negative duplicates"},
+		{name: "unknown target", scenario: "rules:\n  - slot: 1\n    targets: [http://c]\n", wantErr: `unknown target "http://c"`},
+		{name: "invalid fault injection", scenario: "rules:\n  - slot: 1\n    fault_injection: nope=1\n", wantErr: "fault_injection"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := loadTestScenario(t, tc.scenario)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// testScenario has overlapping rules, the first matching one wins.
+const testScenario = `
+rules:
+  - name: withheld
+    slot: 5
+    withhold: true
+  - name: slow
+    slots: 5-10
+    delay_ms: 2000
+    proof_types: [1, 3]
+  - name: target b
+    epoch: 1
+    targets: [http://b/]
+    duplicates: 2
+  - name: faulty
+    epochs: 3-4
+    fault_injection: bad-signature=1
+    proofs_per_block: 0
+`
+
+func TestScenarioApply(t *testing.T) {
+	scenario, submitter, err := loadTestScenario(t, testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaultPlan := func() *proofPlan {
+		return &proofPlan{proofTypes: []ProofType{0, 1, 2, 3}, delay: time.Second, submitter: submitter}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		slot  Slot
+		check func(t *testing.T, plan *proofPlan)
+	}{
+		{
+			name: "no rule",
+			slot: 4,
+			check: func(t *testing.T, plan *proofPlan) {
+				assertPlan(t, plan, false, []ProofType{0, 1, 2, 3}, time.Second, []string{"http://a", "http://b"}, false, 0)
+			},
+		},
+		{
+			name: "first match wins",
+			slot: 5,
+			check: func(t *testing.T, plan *proofPlan) {
+				assertPlan(t, plan, true, []ProofType{0, 1, 2, 3}, time.Second, []string{"http://a", "http://b"}, false, 0)
+			},
+		},
+		{
+			name: "slot range",
+			slot: 10,
+			check: func(t *testing.T, plan *proofPlan) {
+				assertPlan(t, plan, false, []ProofType{1, 3}, 2*time.Second, []string{"http://a", "http://b"}, false, 0)
+			},
+		},
+		{
+			name: "epoch",
+			slot: 63,
+			check: func(t *testing.T, plan *proofPlan) {
+				assertPlan(t, plan, false, []ProofType{0, 1, 2, 3}, time.Second, []string{"http://b"}, false, 2)
+			},
+		},
+		{
+			name: "after the epoch",
+			slot: 64,
+			check: func(t *testing.T, plan *proofPlan) {
+				assertPlan(t, plan, false, []ProofType{0, 1, 2, 3}, time.Second, []string{"http://a", "http://b"}, false, 0)
+			},
+		},
+		{
+			name: "epoch range",
+			slot: 4*32 + 31,
+			check: func(t *testing.T, plan *proofPlan) {
+				assertPlan(t, plan, false, []ProofType{}, time.Second, []string{"http://a", "http://b"}, true, 0)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := defaultPlan()
+			if err := scenario.apply(context.Background(), tc.slot, plan); err != nil {
+				t.Fatal(err)
+			}
+
+			tc.check(t, plan)
+		})
+	}
+}
+
+// assertPlan fails the test if plan differs from the expected one.
+func assertPlan(t *testing.T, plan *proofPlan, withhold bool, proofTypes []ProofType, delay time.Duration, targets []string, faults bool, duplicates int) {
+	t.Helper()
+
+	if plan.withhold != withhold {
+		t.Errorf("withhold: got %t, want %t", plan.withhold, withhold)
+	}
+
+	if !slices.Equal(plan.proofTypes, proofTypes) {
+		t.Errorf("proof types: got %v, want %v", plan.proofTypes, proofTypes)
+	}
+
+	if plan.delay != delay {
+		t.Errorf("delay: got %s, want %s", plan.delay, delay)
+	}
+
+	if got := plan.submitter.URLs(); !slices.Equal(got, targets) {
+		t.Errorf("targets: got %v, want %v", got, targets)
+	}
+
+	if (plan.faults != nil) != faults {
+		t.Errorf("fault injection: got %t, want %t", plan.faults != nil, faults)
+	}
+
+	if plan.duplicates != duplicates {
+		t.Errorf("duplicates: got %d, want %d", plan.duplicates, duplicates)
+	}
+}
+
+func TestScenarioTransitions(t *testing.T) {
+	scenario, submitter, err := loadTestScenario(t, testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	previousLogger := logger
+	logger = slog.New(slog.NewJSONHandler(&logs, nil))
+	t.Cleanup(func() { logger = previousLogger })
+
+	for _, slot := range []Slot{1, 2, 5, 6, 7, 11, 32, 33, 200} {
+		if err := scenario.apply(context.Background(), slot, &proofPlan{submitter: submitter}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A nil scenario leaves the plan alone
+	var noScenario *Scenario
+	if err := noScenario.apply(context.Background(), 5, &proofPlan{}); err != nil {
+		t.Fatal(err)
+	}
+
+	type transition struct {
+		Slot Slot   `json:"slot"`
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+
+	var got []transition
+	decoder := json.NewDecoder(&logs)
+	for decoder.More() {
+		var entry struct {
+			Msg string `json:"msg"`
+			transition
+		}
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+
+		if entry.Msg == "Scenario transition" {
+			got = append(got, entry.transition)
+		}
+	}
+
+	want := []transition{
+		{Slot: 5, From: defaultScenarioRule, To: "withheld"},
+		{Slot: 6, From: "withheld", To: "slow"},
+		{Slot: 11, From: "slow", To: defaultScenarioRule},
+		{Slot: 32, From: defaultScenarioRule, To: "target b"},
+		{Slot: 200, From: "target b", To: defaultScenarioRule},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("transitions:\ngot  %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+)
+
+const (
+	// defaultExecutionProofDomainType is DOMAIN_EXECUTION_PROOF.
+	defaultExecutionProofDomainType = "0x0D000000"
+
+	// forkRefreshInterval bounds how long a fetched fork is trusted, so the
+	// signing domain follows fork transitions.
+	forkRefreshInterval = time.Minute
+)
+
+// Signer signs execution proofs on behalf of a validator.
+type Signer interface {
+	SignExecutionProof(ctx context.Context, proof *ExecutionProof) (*SignedExecutionProof, error)
+}
+
+// newSigner creates the signer selected by the configuration, along with a description for the logs.
+// Keystores or Web3Signer are used if configured, the validator client otherwise,
+// retrying failed signing requests with retry. beaconClient, nil offline, resolves
+// the signing data not set by the configuration.
+func newSigner(cfg Config, beaconClient *BeaconClient, retry retryPolicy) (Signer, string, error) {
+	if cfg.KeystoreDir != "" && cfg.Web3SignerURL != "" {
+		return nil, "", errors.New("-keystore-dir and -web3signer-url are mutually exclusive")
+	}
+
+	if cfg.KeystoreDir == "" && cfg.Web3SignerURL == "" {
+		return NewValidatorClient(cfg.ValidatorClientURL, retry), cfg.ValidatorClientURL, nil
+	}
+
+	domain, err := newSigningDomainProvider(beaconClient, cfg.ExecutionProofDomain, cfg.GenesisValidatorsRoot, cfg.ForkVersion)
+	if err != nil {
+		return nil, "", fmt.Errorf("signing domain: %w", err)
+	}
+
+	indices, err := newValidatorIndexCache(beaconClient, cfg.ValidatorIndices)
+	if err != nil {
+		return nil, "", fmt.Errorf("validator indices: %w", err)
+	}
+
+	if cfg.Web3SignerURL != "" {
+		pubkeys, err := parsePublicKeys(strings.Split(cfg.Web3SignerPubkeys, ","))
+		if err != nil {
+			return nil, "", fmt.Errorf("web3signer public keys: %w", err)
+		}
+
+		return NewWeb3Signer(cfg.Web3SignerURL, pubkeys, indices, domain, retry), "web3signer " + cfg.Web3SignerURL, nil
+	}
+
+	if cfg.KeystorePasswordFile == "" {
+		return nil, "", errors.New("-keystore-password-file is required with -keystore-dir")
+	}
+
+	signer, err := NewKeystoreSigner(cfg.KeystoreDir, cfg.KeystorePasswordFile, indices, domain)
+	if err != nil {
+		return nil, "", fmt.Errorf("keystore signer: %w", err)
+	}
+
+	return signer, fmt.Sprintf("keystores (%d keys in %s)", len(signer.keys), cfg.KeystoreDir), nil
+}
+
+// signingDomainProvider computes the execution proof signing domain from the
+// genesis validators root and current fork reported by a beacon node, unless set.
+type signingDomainProvider struct {
+	beaconClient *BeaconClient
+	domainType   [4]byte
+
+	// forkFixed is set when the fork version is given rather than fetched.
+	forkFixed bool
+
+	mu                    sync.Mutex
+	genesisValidatorsRoot []byte
+	fork                  *Fork
+	forkFetchedAt         time.Time
+}
+
+// newSigningDomainProvider creates a signing domain provider for the given
+// 0x-prefixed 4-byte domain type. genesisValidatorsRoot and forkVersion, if not
+// empty, are used instead of the ones of beaconClient, which may then be nil.
+func newSigningDomainProvider(beaconClient *BeaconClient, domainType string, genesisValidatorsRoot string, forkVersion string) (*signingDomainProvider, error) {
+	decoded, err := decodeFixedHexBytes("domain type", domainType, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &signingDomainProvider{beaconClient: beaconClient}
+	copy(provider.domainType[:], decoded)
+
+	if genesisValidatorsRoot != "" {
+		provider.genesisValidatorsRoot, err = decodeFixedHexBytes("genesis validators root", genesisValidatorsRoot, 32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if forkVersion != "" {
+		version, err := decodeFixedHexBytes("fork version", forkVersion, 4)
+		if err != nil {
+			return nil, err
+		}
+
+		provider.fork = &Fork{PreviousVersion: version, CurrentVersion: version}
+		provider.forkFixed = true
+	}
+
+	if beaconClient == nil && (provider.genesisValidatorsRoot == nil || !provider.forkFixed) {
+		return nil, errors.New("-genesis-validators-root and -fork-version are required without a target beacon node")
+	}
+
+	return provider, nil
+}
+
+// decodeFixedHexBytes decodes the 0x-prefixed hex string s, which must hold size bytes.
+// name describes s in errors.
+func decodeFixedHexBytes(name string, s string, size int) ([]byte, error) {
+	decoded, err := decodeHexBytes(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+
+	if len(decoded) != size {
+		return nil, fmt.Errorf("invalid %s length: got %d, want %d", name, len(decoded), size)
+	}
+
+	return decoded, nil
+}
+
+// forkInfo returns the current fork and the genesis validators root.
+func (p *signingDomainProvider) forkInfo(ctx context.Context) (*Fork, []byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.genesisValidatorsRoot == nil {
+		genesis, err := p.beaconClient.GetGenesis(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get genesis: %w", err)
+		}
+
+		p.genesisValidatorsRoot = genesis.GenesisValidatorsRoot
+	}
+
+	if !p.forkFixed && (p.fork == nil || time.Since(p.forkFetchedAt) > forkRefreshInterval) {
+		fork, err := p.beaconClient.GetFork(ctx, "head")
+		if err != nil {
+			return nil, nil, fmt.Errorf("get fork: %w", err)
+		}
+
+		p.fork = fork
+		p.forkFetchedAt = time.Now()
+	}
+
+	return p.fork, p.genesisValidatorsRoot, nil
+}
+
+// domain returns the signing domain for the current fork.
+func (p *signingDomainProvider) domain(ctx context.Context) ([]byte, error) {
+	fork, genesisValidatorsRoot, err := p.forkInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeDomain(p.domainType, fork.CurrentVersion, genesisValidatorsRoot)
+}
+
+// signingRoot returns the root to sign for proof under the current fork.
+func (p *signingDomainProvider) signingRoot(ctx context.Context, proof *ExecutionProof) ([32]byte, error) {
+	domain, err := p.domain(ctx)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("domain: %w", err)
+	}
+
+	return computeSigningRoot(proof, domain)
+}
+
+// computeDomain implements compute_domain from the consensus specs.
+func computeDomain(domainType [4]byte, forkVersion []byte, genesisValidatorsRoot []byte) ([]byte, error) {
+	forkData := &ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+
+	forkDataRoot, err := forkData.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("fork data root: %w", err)
+	}
+
+	domain := make([]byte, 32)
+	copy(domain, domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+
+	return domain, nil
+}
+
+// computeSigningRoot implements compute_signing_root from the consensus specs.
+func computeSigningRoot(object ssz.HashRoot, domain []byte) ([32]byte, error) {
+	objectRoot, err := object.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("object root: %w", err)
+	}
+
+	signingData := &SigningData{
+		ObjectRoot: objectRoot[:],
+		Domain:     domain,
+	}
+
+	return signingData.HashTreeRoot()
+}
+
+// validatorIndexCache resolves validator indices by public key and caches them.
+type validatorIndexCache struct {
+	beaconClient *BeaconClient
+
+	mu      sync.Mutex
+	indices map[string]uint64
+}
+
+// newValidatorIndexCache creates a validator index cache backed by beaconClient, nil offline,
+// and seeded with known, comma-separated <pubkey>=<index> entries.
+func newValidatorIndexCache(beaconClient *BeaconClient, known string) (*validatorIndexCache, error) {
+	cache := &validatorIndexCache{
+		beaconClient: beaconClient,
+		indices:      make(map[string]uint64),
+	}
+
+	for entry := range strings.SplitSeq(known, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pubkeyStr, indexStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("missing index in %q", entry)
+		}
+
+		pubkey, err := decodeFixedHexBytes("public key", strings.TrimSpace(pubkeyStr), 48)
+		if err != nil {
+			return nil, fmt.Errorf("parse %q: %w", entry, err)
+		}
+
+		index, err := strconv.ParseUint(strings.TrimSpace(indexStr), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse index in %q: %w", entry, err)
+		}
+
+		cache.indices[string(pubkey)] = index
+	}
+
+	if beaconClient == nil && len(cache.indices) == 0 {
+		return nil, errors.New("-validator-indices is required without a target beacon node")
+	}
+
+	return cache, nil
+}
+
+// get returns the validator index of pubkey, looking it up on first use.
+// The lock is not held during the lookup, so a slow beacon node does not block
+// signing with the keys already resolved.
+func (c *validatorIndexCache) get(ctx context.Context, pubkey []byte) (uint64, error) {
+	key := string(pubkey)
+
+	c.mu.Lock()
+	index, ok := c.indices[key]
+	c.mu.Unlock()
+
+	if ok {
+		return index, nil
+	}
+
+	if c.beaconClient == nil {
+		return 0, fmt.Errorf("%w, set it with -validator-indices", errOffline)
+	}
+
+	index, err := c.beaconClient.GetValidatorIndex(ctx, pubkey)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.indices[key] = index
+	c.mu.Unlock()
+
+	return index, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SlotClock maps wall-clock time to beacon chain slots.
+type SlotClock struct {
+	genesisTime   time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+}
+
+// NewSlotClock creates a slot clock from the chain genesis time, slot duration and number of slots per epoch.
+func NewSlotClock(genesisTime time.Time, slotDuration time.Duration, slotsPerEpoch uint64) *SlotClock {
+	return &SlotClock{
+		genesisTime:   genesisTime,
+		slotDuration:  slotDuration,
+		slotsPerEpoch: slotsPerEpoch,
+	}
+}
+
+// CurrentSlot returns the current slot, 0 before genesis.
+func (c *SlotClock) CurrentSlot() Slot {
+	return c.SlotAt(time.Now())
+}
+
+// SlotAt returns the slot at time t, 0 before genesis.
+func (c *SlotClock) SlotAt(t time.Time) Slot {
+	elapsed := t.Sub(c.genesisTime)
+	if elapsed < 0 {
+		return 0
+	}
+
+	return Slot(elapsed / c.slotDuration)
+}
+
+// SlotStart returns the time at which slot starts.
+func (c *SlotClock) SlotStart(slot Slot) time.Time {
+	return c.genesisTime.Add(time.Duration(slot) * c.slotDuration)
+}
+
+// SlotDuration returns the duration of a slot.
+func (c *SlotClock) SlotDuration() time.Duration {
+	return c.slotDuration
+}
+
+// EpochOf returns the epoch of slot.
+func (c *SlotClock) EpochOf(slot Slot) uint64 {
+	return uint64(slot) / c.slotsPerEpoch
+}
+
+// chainConfigProvider provides the chain parameters the slot clock is built from.
+type chainConfigProvider interface {
+	GetGenesis(ctx context.Context) (*Genesis, error)
+	GetSpec(ctx context.Context) (map[string]string, error)
+}
+
+// lazySlotClock fetches the slot clock from a beacon node on first use and caches it,
+// so the prover can start before the beacon node is reachable.
+type lazySlotClock struct {
+	beaconClient chainConfigProvider
+
+	mu    sync.Mutex
+	clock *SlotClock
+}
+
+// newLazySlotClock creates a slot clock fetched from beaconClient on first use.
+func newLazySlotClock(beaconClient chainConfigProvider) *lazySlotClock {
+	return &lazySlotClock{beaconClient: beaconClient}
+}
+
+// get returns the slot clock, fetching it if needed.
+// The lock is not held while fetching, so that a slow beacon node does not
+// block the callers once another one got the clock.
+func (l *lazySlotClock) get(ctx context.Context) (*SlotClock, error) {
+	l.mu.Lock()
+	clock := l.clock
+	l.mu.Unlock()
+
+	if clock != nil {
+		return clock, nil
+	}
+
+	clock, err := l.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Keep the clock of the first caller to fetch it, they are the same anyway
+	if l.clock == nil {
+		l.clock = clock
+	}
+
+	return l.clock, nil
+}
+
+// fetch builds the slot clock from the genesis and spec of the beacon node.
+func (l *lazySlotClock) fetch(ctx context.Context) (*SlotClock, error) {
+	genesis, err := l.beaconClient.GetGenesis(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get genesis: %w", err)
+	}
+
+	spec, err := l.beaconClient.GetSpec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get spec: %w", err)
+	}
+
+	secondsPerSlot, err := strconv.ParseUint(spec["SECONDS_PER_SLOT"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse SECONDS_PER_SLOT: %w", err)
+	}
+
+	if secondsPerSlot == 0 {
+		return nil, fmt.Errorf("invalid SECONDS_PER_SLOT: %d", secondsPerSlot)
+	}
+
+	slotsPerEpoch, err := strconv.ParseUint(spec["SLOTS_PER_EPOCH"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse SLOTS_PER_EPOCH: %w", err)
+	}
+
+	if slotsPerEpoch == 0 {
+		return nil, fmt.Errorf("invalid SLOTS_PER_EPOCH: %d", slotsPerEpoch)
+	}
+
+	return NewSlotClock(time.Unix(int64(genesis.GenesisTime), 0), time.Duration(secondsPerSlot)*time.Second, slotsPerEpoch), nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newStallingBeaconNode serves a 12-second slot chain config, the first
+// genesis request stalling until release is closed. The genesis requests
+// are counted in calls.
+func newStallingBeaconNode(t *testing.T, calls *atomic.Int32, release chan struct{}) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		fmt.Fprint(w, `{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x0000000000000000000000000000000000000000000000000000000000000000","genesis_fork_version":"0x00000000"}}`)
+	})
+	mux.HandleFunc("GET /eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32"}}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLazySlotClockDoesNotBlockOnSlowFetch(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	clock := newLazySlotClock(NewBeaconClient(newStallingBeaconNode(t, &calls, release).URL, newRetryPolicy(1)))
+
+	stalled := make(chan error, 1)
+	go func() {
+		_, err := clock.get(context.Background())
+		stalled <- err
+	}()
+
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Another caller gets the clock while the first fetch is stalled
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	slotClock, err := clock.get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := slotClock.SlotDuration(); got != 12*time.Second {
+		t.Errorf("slot duration: got %s, want 12s", got)
+	}
+
+	close(release)
+	if err := <-stalled; err != nil {
+		t.Fatal(err)
+	}
+
+	// The clock is cached from then on
+	if again, err := clock.get(ctx); err != nil || again != slotClock {
+		t.Errorf("cached clock: got %p (error %v), want %p", again, err, slotClock)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("genesis fetches: got %d, want 2", got)
+	}
+}
+
+func TestLazySlotClockRetriesAfterError(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	release := make(chan struct{})
+	close(release)
+
+	node := newStallingBeaconNode(t, &calls, release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			http.Error(w, "unreachable", http.StatusBadRequest)
+			return
+		}
+
+		node.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	clock := newLazySlotClock(NewBeaconClient(server.URL, newRetryPolicy(1)))
+
+	if _, err := clock.get(context.Background()); err == nil {
+		t.Fatal("no error from an unreachable beacon node")
+	}
+
+	// The next call fetches again
+	healthy.Store(true)
+
+	if _, err := clock.get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// sourceCheckInterval is how often the active source is compared to the other
+	// sources, to fail over if it falls behind and fail back to a preferred one.
+	sourceCheckInterval = 12 * time.Second
+
+	// sourceProbeTimeout bounds the health and head requests used to rank sources.
+	sourceProbeTimeout = 5 * time.Second
+
+	// seenRootsSlots is how many slots block roots are remembered for deduplication.
+	seenRootsSlots = 2 * maxBackfillSlots
+)
+
+// BlockSource sources the blocks to prove: a pool of beacon nodes, or files when offline.
+type BlockSource interface {
+	chainConfigProvider
+
+	// GetSignedBlindedBeaconBlock returns the block with the given ID, or errBlockNotFound.
+	GetSignedBlindedBeaconBlock(ctx context.Context, blockID string) (*SignedBlindedBeaconBlock, error)
+
+	// subscribeToBlockGossip announces blocks until ctx is done or no block is left.
+	subscribeToBlockGossip(ctx context.Context) <-chan BlockEventData
+
+	// headSlot returns the slot of the head block.
+	headSlot(ctx context.Context) (Slot, error)
+
+	// slotEmpty reports whether slot is confirmed to have no block.
+	slotEmpty(ctx context.Context, slot Slot) (bool, error)
+
+	// URLs returns where blocks are sourced from.
+	URLs() []string
+}
+
+// SourcePool sources blocks from an ordered list of beacon nodes.
+// Block events are streamed from the first healthy source that is not behind
+// the others, failing over to the next one when the stream breaks or falls behind,
+// and back to a preferred one once it is healthy and caught up again.
+// Blocks are fetched from the active source first, then from the other ones.
+type SourcePool struct {
+	sources       []*BeaconClient
+	maxHeadLag    Slot
+	checkInterval time.Duration
+
+	active atomic.Int32
+}
+
+// NewSourcePool creates a pool of sources, in order of preference, fetching blocks with blockEncoding (ssz or json).
+// A source whose head is more than maxHeadLag slots behind another one's is failed over, 0 disables this check.
+func NewSourcePool(sources []*BeaconClient, maxHeadLag uint64, blockEncoding string) (*SourcePool, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no source beacon node")
+	}
+
+	if blockEncoding != blockEncodingSSZ && blockEncoding != blockEncodingJSON {
+		return nil, fmt.Errorf("unknown block encoding %q (want %s or %s)", blockEncoding, blockEncodingSSZ, blockEncodingJSON)
+	}
+
+	for _, source := range sources {
+		source.blockEncoding = blockEncoding
+	}
+
+	pool := &SourcePool{
+		sources:       sources,
+		maxHeadLag:    Slot(maxHeadLag),
+		checkInterval: sourceCheckInterval,
+	}
+
+	pool.setActive(0)
+	return pool, nil
+}
+
+// Active returns the source currently streaming block events, or the preferred one before the first connection.
+func (p *SourcePool) Active() *BeaconClient {
+	return p.sources[p.active.Load()]
+}
+
+// SSEConnected reports whether the block SSE stream of the active source is connected.
+func (p *SourcePool) SSEConnected() bool {
+	return p.Active().SSEConnected()
+}
+
+// URLs returns the URLs of the sources, in order of preference.
+func (p *SourcePool) URLs() []string {
+	urls := make([]string, 0, len(p.sources))
+	for _, source := range p.sources {
+		urls = append(urls, source.baseURL)
+	}
+
+	return urls
+}
+
+// setActive records index as the active source.
+func (p *SourcePool) setActive(index int) {
+	p.active.Store(int32(index))
+
+	for i, source := range p.sources {
+		value := 0.0
+		if i == index {
+			value = 1
+		}
+
+		activeSource.WithLabelValues(source.baseURL).Set(value)
+	}
+}
+
+// subscribeToBlockGossip subscribes to block SSE events.
+// The subscription survives source restarts and failures: whenever the stream
+// drops or falls behind, it moves to the best available source, reconnecting to
+// the same one if there is no other, then replays the blocks that were produced
+// while it was disconnected. Every reconnection waits for an exponential backoff
+// with jitter, reset once a connection caught up, so that failing sources are
+// not hammered. Once a source preferred to the active one is healthy and caught
+// up again, the subscription moves back to it. Events are deduplicated by block
+// root, so a block is never emitted twice. The returned channel is closed once
+// ctx is cancelled.
+func (p *SourcePool) subscribeToBlockGossip(ctx context.Context) <-chan BlockEventData {
+	events := make(chan BlockEventData)
+
+	go func() {
+		defer close(events)
+
+		var (
+			lastSlot Slot
+			seen     bool
+			roots    = make(map[Root]Slot)
+		)
+
+		send := func(event BlockEventData) error {
+			if _, ok := roots[event.Block]; ok {
+				return nil
+			}
+			roots[event.Block] = event.Slot
+
+			if event.Slot > lastSlot || !seen {
+				lastSlot = event.Slot
+				seen = true
+
+				for root, slot := range roots {
+					if slot+seenRootsSlots < lastSlot {
+						delete(roots, root)
+					}
+				}
+			}
+
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		bo := newBackoff(sseReconnectMinBackoff, sseReconnectMaxBackoff)
+		current := -1
+		for {
+			index := p.selectSource(ctx, current)
+			if ctx.Err() != nil {
+				return
+			}
+
+			switch {
+			case index < 0:
+				delay := bo.next()
+				logger.Warn("No healthy source beacon node, retrying", "retryIn", delay)
+				if !sleep(ctx, delay) {
+					return
+				}
+				continue
+
+			case index == current:
+				delay := bo.next()
+				logger.Warn("SSE stream disconnected, reconnecting", "event", blockEvent, "source", p.sources[index].baseURL, "retryIn", delay)
+				if !sleep(ctx, delay) {
+					return
+				}
+
+			case current >= 0:
+				delay := bo.next()
+				logger.Warn("Failing over to another source beacon node", "from", p.sources[current].baseURL, "to", p.sources[index].baseURL, "retryIn", delay)
+				if !sleep(ctx, delay) {
+					return
+				}
+				sourceFailovers.Inc()
+			}
+
+			current = index
+			p.setActive(index)
+			source := p.sources[index]
+
+			onConnect := func() error {
+				if !seen {
+					bo.reset()
+					return nil
+				}
+
+				afterSlot := lastSlot
+				replayed, err := source.backfillBlockGossip(ctx, afterSlot, send)
+				if err != nil {
+					return fmt.Errorf("backfill: %w", err)
+				}
+
+				// Only a connection that fully caught up counts as recovered,
+				// a source failing every backfill keeps backing off.
+				bo.reset()
+
+				if replayed > 0 {
+					logger.Info("Replayed missed blocks", "count", replayed, "afterSlot", afterSlot, "source", source.baseURL)
+				}
+
+				return nil
+			}
+
+			err := p.stream(ctx, index, onConnect, send)
+			if ctx.Err() != nil {
+				return
+			}
+
+			logger.Warn("SSE stream ended", "event", blockEvent, "source", source.baseURL, "error", err)
+		}
+	}()
+
+	return events
+}
+
+// stream streams block events from the source at index until the stream breaks,
+// the source falls behind the other ones or a preferred source is available again.
+func (p *SourcePool) stream(ctx context.Context, index int, onConnect func() error, onEvent func(BlockEventData) error) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	streamCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	if len(p.sources) > 1 && (p.maxHeadLag > 0 || index > 0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.monitor(streamCtx, index, cancel)
+		}()
+	}
+
+	err := p.sources[index].streamBlockGossip(streamCtx, onConnect, onEvent)
+	if cause := context.Cause(streamCtx); ctx.Err() == nil && cause != nil {
+		return cause
+	}
+
+	return err
+}
+
+// monitor cancels the stream of the source at index once its head falls more
+// than maxHeadLag slots behind another source's, or once a source preferred to
+// it qualifies again.
+func (p *SourcePool) monitor(ctx context.Context, index int, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		heads := p.heads(ctx)
+
+		if best := p.best(heads, -1); best >= 0 && best < index {
+			cancel(fmt.Errorf("preferred source %s is available again", p.sources[best].baseURL))
+			return
+		}
+
+		if p.maxHeadLag == 0 || heads[index] < 0 {
+			continue
+		}
+
+		for i, head := range heads {
+			if i != index && head > heads[index]+int64(p.maxHeadLag) {
+				cancel(fmt.Errorf("source head at slot %d is behind %s at slot %d", heads[index], p.sources[i].baseURL, head))
+				return
+			}
+		}
+	}
+}
+
+// selectSource returns the index of the best source, -1 if none is healthy.
+// The best source is the first healthy one, in order of preference, whose head
+// is at most maxHeadLag slots behind the highest head. current, the source that
+// just failed, is only picked again if no other source qualifies.
+func (p *SourcePool) selectSource(ctx context.Context, current int) int {
+	if len(p.sources) == 1 {
+		if current < 0 {
+			return 0
+		}
+
+		return current
+	}
+
+	return p.best(p.heads(ctx), current)
+}
+
+// best returns the index of the first source qualifying given the source heads,
+// preferring any other source to current, -1 if none qualifies.
+func (p *SourcePool) best(heads []int64, current int) int {
+	highest := int64(-1)
+	for _, head := range heads {
+		highest = max(highest, head)
+	}
+
+	if highest < 0 {
+		return -1
+	}
+
+	fallback := -1
+	for i, head := range heads {
+		if head < 0 || (p.maxHeadLag > 0 && head+int64(p.maxHeadLag) < highest) {
+			continue
+		}
+
+		if i != current {
+			return i
+		}
+
+		fallback = i
+	}
+
+	return fallback
+}
+
+// heads returns the head slot of every healthy source, -1 for the unhealthy ones.
+func (p *SourcePool) heads(ctx context.Context) []int64 {
+	ctx, cancel := context.WithTimeout(ctx, sourceProbeTimeout)
+	defer cancel()
+
+	heads := make([]int64, len(p.sources))
+
+	var wg sync.WaitGroup
+	for i, source := range p.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			heads[i] = -1
+
+			if err := source.CheckHealth(ctx); err != nil {
+				logger.Debug("Source beacon node unhealthy", "source", source.baseURL, "error", err)
+				return
+			}
+
+			head, err := source.GetBlockHeader(ctx, "head")
+			if err != nil {
+				logger.Debug("Failed to get source head", "source", source.baseURL, "error", err)
+				return
+			}
+
+			heads[i] = int64(head.Header.Message.Slot)
+		}()
+	}
+	wg.Wait()
+
+	return heads
+}
+
+// GetSignedBlindedBeaconBlock fetches a signed blinded block by ID from the active source,
+// falling back to the other sources in order if it is missing or the source fails.
+func (p *SourcePool) GetSignedBlindedBeaconBlock(ctx context.Context, blockID string) (*SignedBlindedBeaconBlock, error) {
+	active := int(p.active.Load())
+
+	var errs []error
+	for i := range p.sources {
+		// Start from the active source, then the others in order of preference
+		index := i
+		switch {
+		case i == 0:
+			index = active
+		case i <= active:
+			index = i - 1
+		}
+
+		source := p.sources[index]
+
+		block, err := source.GetSignedBlindedBeaconBlock(ctx, blockID)
+		if err == nil {
+			return block, nil
+		}
+
+		if !shouldFallBack(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", source.baseURL, err))
+
+		if i < len(p.sources)-1 {
+			sourceFetchFallbacks.Inc()
+			logger.Debug("Block fetch failed, trying next source", "blockID", blockID, "source", source.baseURL, "error", err)
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// headSlot returns the highest head slot of the healthy sources.
+func (p *SourcePool) headSlot(ctx context.Context) (Slot, error) {
+	head := int64(-1)
+	for _, sourceHead := range p.heads(ctx) {
+		head = max(head, sourceHead)
+	}
+
+	if head < 0 {
+		return 0, errors.New("no healthy source")
+	}
+
+	return Slot(head), nil
+}
+
+// slotEmpty reports whether the first source able to answer has no block at slot.
+func (p *SourcePool) slotEmpty(ctx context.Context, slot Slot) (bool, error) {
+	headers, err := firstSuccess(ctx, p.sources, func(source *BeaconClient, ctx context.Context) ([]*BlockHeaderData, error) {
+		return source.GetBlockHeadersAtSlot(ctx, slot)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(headers) == 0, nil
+}
+
+// shouldFallBack reports whether a block fetch error warrants trying another source:
+// missing blocks, and the errors still failing once retried.
+func shouldFallBack(err error) bool {
+	return errors.Is(err, errNotFound) || isRetryable(err)
+}
+
+// GetGenesis fetches the chain genesis information from the first source able to answer.
+func (p *SourcePool) GetGenesis(ctx context.Context) (*Genesis, error) {
+	return firstSuccess(ctx, p.sources, (*BeaconClient).GetGenesis)
+}
+
+// GetSpec fetches the chain configuration from the first source able to answer.
+func (p *SourcePool) GetSpec(ctx context.Context) (map[string]string, error) {
+	return firstSuccess(ctx, p.sources, (*BeaconClient).GetSpec)
+}
+
+// firstSuccess calls get on each source in turn and returns the first successful result.
+func firstSuccess[T any](ctx context.Context, sources []*BeaconClient, get func(*BeaconClient, context.Context) (T, error)) (T, error) {
+	var errs []error
+	for _, source := range sources {
+		value, err := get(source, ctx)
+		if err == nil {
+			return value, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", source.baseURL, err))
+	}
+
+	var zero T
+	return zero, errors.Join(errs...)
+}
+
+// sleep waits for d, returning false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
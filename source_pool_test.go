@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testSource is a source beacon node streaming a block event for its head on every SSE connection.
+type testSource struct {
+	server *httptest.Server
+	url    string
+
+	healthy     atomic.Bool
+	sseDown     atomic.Bool
+	head        atomic.Uint64
+	connections atomic.Int32
+}
+
+func newTestSource(t *testing.T, head Slot) *testSource {
+	t.Helper()
+
+	source := new(testSource)
+	source.healthy.Store(true)
+	source.head.Store(uint64(head))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/node/health", func(w http.ResponseWriter, r *http.Request) {
+		if !source.healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	mux.HandleFunc("GET /eth/v1/beacon/headers/head", func(w http.ResponseWriter, r *http.Request) {
+		if !source.healthy.Load() {
+			http.Error(w, `{"code":503,"message":"syncing"}`, http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprintf(w, `{"data":{"root":"%#x","canonical":true,"header":{"message":{"slot":"%d","proposer_index":"1","parent_root":"0x","state_root":"0x","body_root":"0x"},"signature":"0x"}}}`, testBlockRoot(Slot(source.head.Load())), source.head.Load())
+	})
+	mux.HandleFunc("GET /eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		source.connections.Add(1)
+
+		if !source.healthy.Load() || source.sseDown.Load() {
+			http.Error(w, `{"code":503,"message":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: block\ndata: {\"slot\":\"%d\",\"block\":\"%#x\"}\n\n", source.head.Load(), testBlockRoot(Slot(source.head.Load())))
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})
+
+	source.server = httptest.NewServer(mux)
+	t.Cleanup(source.server.Close)
+
+	source.url = source.server.URL
+	return source
+}
+
+// testBlockRoot returns a block root identifying slot.
+func testBlockRoot(slot Slot) Root {
+	var root Root
+	root[0] = byte(slot)
+	return root
+}
+
+func newTestSourcePool(t *testing.T, sources ...*testSource) *SourcePool {
+	t.Helper()
+
+	clients := make([]*BeaconClient, 0, len(sources))
+	for _, source := range sources {
+		clients = append(clients, NewBeaconClient(source.url, newRetryPolicy(1)))
+	}
+
+	pool, err := NewSourcePool(clients, 0, blockEncodingJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.checkInterval = 50 * time.Millisecond
+
+	return pool
+}
+
+// waitActive waits for the pool to stream from the source at index.
+func waitActive(t *testing.T, pool *SourcePool, index int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for int(pool.active.Load()) != index || !pool.SSEConnected() {
+		if time.Now().After(deadline) {
+			t.Fatalf("active source: got %d (connected %t), want %d", pool.active.Load(), pool.SSEConnected(), index)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSourcePoolFailover(t *testing.T) {
+	preferred := newTestSource(t, 10)
+	other := newTestSource(t, 10)
+	preferred.healthy.Store(false)
+
+	pool := newTestSourcePool(t, preferred, other)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := pool.subscribeToBlockGossip(ctx)
+
+	// The preferred source is down, events come from the other one
+	waitActive(t, pool, 1)
+
+	select {
+	case event := <-events:
+		if event.Slot != 10 || event.Block != testBlockRoot(10) {
+			t.Errorf("event: got slot %d root %#x, want slot 10 root %#x", event.Slot, event.Block, testBlockRoot(10))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block event")
+	}
+
+	// Once the preferred source is back, the pool fails back to it
+	preferred.healthy.Store(true)
+	waitActive(t, pool, 0)
+
+	// When the preferred source goes down again, the pool fails over and
+	// replays the block produced meanwhile, announced once only
+	other.head.Store(11)
+	preferred.healthy.Store(false)
+	preferred.server.CloseClientConnections()
+
+	waitActive(t, pool, 1)
+
+	select {
+	case event := <-events:
+		if event.Slot != 11 || event.Block != testBlockRoot(11) {
+			t.Errorf("event: got slot %d root %#x, want slot 11 root %#x", event.Slot, event.Block, testBlockRoot(11))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block event")
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event for slot %d", event.Slot)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSourcePoolFailoverBacksOff(t *testing.T) {
+	first := newTestSource(t, 10)
+	second := newTestSource(t, 10)
+	first.sseDown.Store(true)
+	second.sseDown.Store(true)
+
+	pool := newTestSourcePool(t, first, second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sseReconnectMinBackoff*3/2)
+	defer cancel()
+
+	for range pool.subscribeToBlockGossip(ctx) {
+		t.Error("unexpected block event")
+	}
+
+	// The first connection is immediate, the next ones wait at least the minimum backoff
+	if got := first.connections.Load() + second.connections.Load(); got > 2 {
+		t.Errorf("SSE connections: got %d, want at most 2", got)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Submission policies, deciding how many targets must accept a proof for it to count as submitted.
+const (
+	submissionPolicyAny    = "any"
+	submissionPolicyQuorum = "quorum"
+	submissionPolicyAll    = "all"
+)
+
+// proofTarget is where proofs are submitted: a beacon node, or files when offline.
+type proofTarget interface {
+	URL() string
+	SubmitSignedExecutionProof(ctx context.Context, proof *SignedExecutionProof) error
+	CheckHealth(ctx context.Context) error
+}
+
+// Submitter submits signed execution proofs to every target concurrently.
+type Submitter struct {
+	targets []proofTarget
+	policy  string
+}
+
+// NewSubmitter creates a submitter fanning out to targets.
+// policy is one of any, quorum (a strict majority) or all.
+func NewSubmitter(targets []proofTarget, policy string) (*Submitter, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("no target beacon node")
+	}
+
+	switch policy {
+	case submissionPolicyAny, submissionPolicyQuorum, submissionPolicyAll:
+	default:
+		return nil, fmt.Errorf("unknown submission policy %q (want %s, %s or %s)", policy, submissionPolicyAny, submissionPolicyQuorum, submissionPolicyAll)
+	}
+
+	return &Submitter{
+		targets: targets,
+		policy:  policy,
+	}, nil
+}
+
+// Submit submits proof to every target and waits for all of them to answer.
+// It returns an error listing the failed targets if the submission policy is not met.
+func (s *Submitter) Submit(ctx context.Context, proof *SignedExecutionProof) error {
+	proofType := proofTypeLabel(proof.Message.ProofType)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, target := range s.targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := target.SubmitSignedExecutionProof(ctx, proof)
+			targetSubmitDuration.WithLabelValues(target.URL()).Observe(time.Since(start).Seconds())
+
+			if err != nil {
+				targetSubmissions.WithLabelValues(target.URL(), proofType, submissionResultFailure).Inc()
+
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", target.URL(), err))
+				mu.Unlock()
+				return
+			}
+
+			targetSubmissions.WithLabelValues(target.URL(), proofType, submissionResultSuccess).Inc()
+		}()
+	}
+	wg.Wait()
+
+	accepted := len(s.targets) - len(errs)
+	if !s.satisfied(accepted) {
+		return fmt.Errorf("accepted by %d of %d targets, policy %s not met: %w", accepted, len(s.targets), s.policy, errors.Join(errs...))
+	}
+
+	for _, err := range errs {
+		logger.Warn("Target rejected proof", "proofType", proof.Message.ProofType, "error", err)
+	}
+
+	return nil
+}
+
+// satisfied returns whether ok targets out of all of them meet the submission policy.
+func (s *Submitter) satisfied(ok int) bool {
+	switch s.policy {
+	case submissionPolicyAny:
+		return ok >= 1
+	case submissionPolicyQuorum:
+		return ok > len(s.targets)/2
+	default:
+		return ok == len(s.targets)
+	}
+}
+
+// URLs returns the URLs of the targets.
+func (s *Submitter) URLs() []string {
+	urls := make([]string, 0, len(s.targets))
+	for _, target := range s.targets {
+		urls = append(urls, target.URL())
+	}
+
+	return urls
+}
+
+// withTargets returns a submitter with the same policy, submitting to the targets with the given URLs only.
+func (s *Submitter) withTargets(urls []string) (*Submitter, error) {
+	targets := make([]proofTarget, 0, len(urls))
+	for _, url := range urls {
+		url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+
+		index := slices.IndexFunc(s.targets, func(target proofTarget) bool {
+			return target.URL() == url
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("unknown target %q (want one of %s)", url, strings.Join(s.URLs(), ", "))
+		}
+
+		targets = append(targets, s.targets[index])
+	}
+
+	return NewSubmitter(targets, s.policy)
+}
@@ -4,18 +4,66 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
 )
 
-const blobCommitmentVersionKZG uint8 = 0x01
+// The SSZ encoding of these types is generated into types_encoding.go by sszgen, installed with
+// go install github.com/prysmaticlabs/fastssz/sszgen@v0.0.0-20251103153600-259302269bfc.
+// sszgen casts uint8 aliases to uint64, which the rewrite reverts.
+//
+//go:generate sszgen --path types.go --objs Attestation,AttestationData,AttesterSlashing,BLSToExecutionChange,BeaconBlockHeader,BlindedBeaconBlock,BlindedBeaconBlockBody,Checkpoint,Consolidation,Deposit,DepositData,Eth1Data,Eth1Deposit,ExecutionPayloadHeader,ExecutionProof,ExecutionRequests,ForkData,IndexedAttestation,NewPayloadRequestHeader,ProposerSlashing,PublicInput,SignedBLSToExecutionChange,SignedBeaconBlockHeader,SignedBlindedBeaconBlock,SignedExecutionProof,SignedVoluntaryExit,SigningData,SyncAggregate,VoluntaryExit,Withdrawal
+//go:generate gofmt -w -r "uint64(e.ProofType) -> uint8(e.ProofType)" types_encoding.go
+
+// errMissingField is returned when decoding JSON lacking a container or list item
+// that its SSZ encoding and hash tree root require.
+var errMissingField = errors.New("missing field")
+
+const (
+	blobCommitmentVersionKZG uint8 = 0x01
+
+	// maxProofSize is MAX_PROOF_SIZE, the maximum length of an execution proof's proof_data.
+	maxProofSize = 1 << 20
+)
 
 type (
+	// BlindedBlockBeaconAPIResponse is decoded in two steps, so the fork is known before the block is.
 	BlindedBlockBeaconAPIResponse struct {
-		Data *SignedBlindedBeaconBlock `json:"data"`
+		Version string          `json:"version"`
+		Data    json.RawMessage `json:"data"`
+	}
+
+	BlockHeaderBeaconAPIResponse struct {
+		Data *BlockHeaderData `json:"data"`
+	}
+
+	BlockHeadersBeaconAPIResponse struct {
+		Data []*BlockHeaderData `json:"data"`
+	}
+
+	BlockHeaderData struct {
+		Root   Root                     `json:"root"`
+		Header *SignedBeaconBlockHeader `json:"header"`
+	}
+
+	SignedBeaconBlockHeader struct {
+		Message   *BeaconBlockHeader `json:"message"`
+		Signature []byte             `json:"signature" ssz-size:"96"`
+	}
+
+	BeaconBlockHeader struct {
+		Slot          Slot   `json:"slot"`
+		ProposerIndex uint64 `json:"proposer_index"`
+		ParentRoot    []byte `json:"parent_root" ssz-size:"32"`
+		StateRoot     []byte `json:"state_root" ssz-size:"32"`
+		BodyRoot      []byte `json:"body_root" ssz-size:"32"`
 	}
 
 	NewPayloadRequestHeader struct {
@@ -25,20 +73,115 @@ type (
 		ExecutionRequests      *ExecutionRequests
 	}
 
+	// SignedBlindedBeaconBlock is an Electra (or Fulu, identical) signed blinded beacon block.
 	SignedBlindedBeaconBlock struct {
-		Message *BlindedBeaconBlock `json:"message"`
+		Message   *BlindedBeaconBlock `json:"message"`
+		Signature []byte              `json:"signature" ssz-size:"96"`
 	}
 
 	BlindedBeaconBlock struct {
-		Slot       Slot                    `json:"slot"`
-		ParentRoot []byte                  `json:"parent_root" ssz-size:"32"`
-		Body       *BlindedBeaconBlockBody `json:"body"`
+		Slot          Slot                    `json:"slot"`
+		ProposerIndex uint64                  `json:"proposer_index"`
+		ParentRoot    []byte                  `json:"parent_root" ssz-size:"32"`
+		StateRoot     []byte                  `json:"state_root" ssz-size:"32"`
+		Body          *BlindedBeaconBlockBody `json:"body"`
 	}
 
 	BlindedBeaconBlockBody struct {
-		ExecutionPayloadHeader *ExecutionPayloadHeader `json:"execution_payload_header"`
-		BlobKzgCommitments     [][]byte                `json:"blob_kzg_commitments,omitempty" ssz-max:"4096" ssz-size:"?,48"`
-		ExecutionRequests      *ExecutionRequests      `json:"execution_requests"`
+		RandaoReveal           []byte                        `json:"randao_reveal" ssz-size:"96"`
+		Eth1Data               *Eth1Data                     `json:"eth1_data"`
+		Graffiti               []byte                        `json:"graffiti" ssz-size:"32"`
+		ProposerSlashings      []*ProposerSlashing           `json:"proposer_slashings" ssz-max:"16"`
+		AttesterSlashings      []*AttesterSlashing           `json:"attester_slashings" ssz-max:"1"`
+		Attestations           []*Attestation                `json:"attestations" ssz-max:"8"`
+		Deposits               []*Eth1Deposit                `json:"deposits" ssz-max:"16"`
+		VoluntaryExits         []*SignedVoluntaryExit        `json:"voluntary_exits" ssz-max:"16"`
+		SyncAggregate          *SyncAggregate                `json:"sync_aggregate"`
+		ExecutionPayloadHeader *ExecutionPayloadHeader       `json:"execution_payload_header"`
+		BLSToExecutionChanges  []*SignedBLSToExecutionChange `json:"bls_to_execution_changes" ssz-max:"16"`
+		BlobKzgCommitments     [][]byte                      `json:"blob_kzg_commitments,omitempty" ssz-max:"4096" ssz-size:"?,48"`
+		ExecutionRequests      *ExecutionRequests            `json:"execution_requests"`
+	}
+
+	Eth1Data struct {
+		DepositRoot  []byte `json:"deposit_root" ssz-size:"32"`
+		DepositCount uint64 `json:"deposit_count"`
+		BlockHash    []byte `json:"block_hash" ssz-size:"32"`
+	}
+
+	ProposerSlashing struct {
+		SignedHeader1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+		SignedHeader2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+	}
+
+	AttesterSlashing struct {
+		Attestation1 *IndexedAttestation `json:"attestation_1"`
+		Attestation2 *IndexedAttestation `json:"attestation_2"`
+	}
+
+	IndexedAttestation struct {
+		AttestingIndices []uint64         `json:"attesting_indices" ssz-max:"131072"`
+		Data             *AttestationData `json:"data"`
+		Signature        []byte           `json:"signature" ssz-size:"96"`
+	}
+
+	Attestation struct {
+		AggregationBits []byte           `json:"aggregation_bits" ssz:"bitlist" ssz-max:"131072"`
+		Data            *AttestationData `json:"data"`
+		Signature       []byte           `json:"signature" ssz-size:"96"`
+		CommitteeBits   []byte           `json:"committee_bits" ssz-size:"8"`
+	}
+
+	AttestationData struct {
+		Slot            Slot        `json:"slot"`
+		Index           uint64      `json:"index"`
+		BeaconBlockRoot []byte      `json:"beacon_block_root" ssz-size:"32"`
+		Source          *Checkpoint `json:"source"`
+		Target          *Checkpoint `json:"target"`
+	}
+
+	Checkpoint struct {
+		Epoch uint64 `json:"epoch"`
+		Root  []byte `json:"root" ssz-size:"32"`
+	}
+
+	// Eth1Deposit is a deposit processed from the deposit contract, not to be confused with a deposit request.
+	Eth1Deposit struct {
+		Proof [][]byte     `json:"proof" ssz-size:"33,32"`
+		Data  *DepositData `json:"data"`
+	}
+
+	DepositData struct {
+		Pubkey                []byte `json:"pubkey" ssz-size:"48"`
+		WithdrawalCredentials []byte `json:"withdrawal_credentials" ssz-size:"32"`
+		Amount                uint64 `json:"amount"`
+		Signature             []byte `json:"signature" ssz-size:"96"`
+	}
+
+	SignedVoluntaryExit struct {
+		Message   *VoluntaryExit `json:"message"`
+		Signature []byte         `json:"signature" ssz-size:"96"`
+	}
+
+	VoluntaryExit struct {
+		Epoch          uint64 `json:"epoch"`
+		ValidatorIndex uint64 `json:"validator_index"`
+	}
+
+	SyncAggregate struct {
+		SyncCommitteeBits      []byte `json:"sync_committee_bits" ssz-size:"64"`
+		SyncCommitteeSignature []byte `json:"sync_committee_signature" ssz-size:"96"`
+	}
+
+	SignedBLSToExecutionChange struct {
+		Message   *BLSToExecutionChange `json:"message"`
+		Signature []byte                `json:"signature" ssz-size:"96"`
+	}
+
+	BLSToExecutionChange struct {
+		ValidatorIndex     uint64 `json:"validator_index"`
+		FromBLSPubkey      []byte `json:"from_bls_pubkey" ssz-size:"48"`
+		ToExecutionAddress []byte `json:"to_execution_address" ssz-size:"20"`
 	}
 
 	ExecutionPayloadHeader struct {
@@ -88,7 +231,7 @@ type (
 	}
 
 	ExecutionProof struct {
-		ProofData   []byte       `json:"proof_data"`
+		ProofData   []byte       `json:"proof_data" ssz-max:"1048576"`
 		ProofType   ProofType    `json:"proof_type"`
 		PublicInput *PublicInput `json:"public_input"`
 	}
@@ -96,7 +239,7 @@ type (
 	SignedExecutionProof struct {
 		Message        *ExecutionProof `json:"message"`
 		ValidatorIndex uint64          `json:"validator_index"`
-		Signature      []byte          `json:"signature"` // 96 bytes
+		Signature      []byte          `json:"signature" ssz-size:"96"`
 	}
 
 	// ExecutionProofRequest is the request body for signing an execution proof.
@@ -110,12 +253,60 @@ type (
 	}
 
 	PublicInput struct {
-		NewPayloadRequestRoot []byte `json:"new_payload_request_root,omitempty"`
+		NewPayloadRequestRoot []byte `json:"new_payload_request_root,omitempty" ssz-size:"32"`
+	}
+
+	ForkData struct {
+		CurrentVersion        []byte `ssz-size:"4"`
+		GenesisValidatorsRoot []byte `ssz-size:"32"`
+	}
+
+	SigningData struct {
+		ObjectRoot []byte `ssz-size:"32"`
+		Domain     []byte `ssz-size:"32"`
+	}
+
+	GenesisBeaconAPIResponse struct {
+		Data *Genesis `json:"data"`
+	}
+
+	Genesis struct {
+		GenesisTime           uint64 `json:"genesis_time"`
+		GenesisValidatorsRoot []byte `json:"genesis_validators_root"`
+		GenesisForkVersion    []byte `json:"genesis_fork_version"`
+	}
+
+	ForkBeaconAPIResponse struct {
+		Data *Fork `json:"data"`
+	}
+
+	Fork struct {
+		PreviousVersion []byte `json:"previous_version"`
+		CurrentVersion  []byte `json:"current_version"`
+		Epoch           uint64 `json:"epoch"`
+	}
+
+	SpecBeaconAPIResponse struct {
+		Data map[string]any `json:"data"`
+	}
+
+	ValidatorBeaconAPIResponse struct {
+		Data *ValidatorData `json:"data"`
+	}
+
+	ValidatorData struct {
+		Index uint64 `json:"index"`
 	}
 
 	BlockEventData struct {
 		Slot  Slot `json:"slot"`
 		Block Root `json:"block"`
+
+		// receivedAt is when the event was received, zero if unknown.
+		receivedAt time.Time
+
+		// backfilled is set on events replayed after a reconnection rather than received live.
+		backfilled bool
 	}
 
 	ProofType uint8
@@ -124,12 +315,8 @@ type (
 	Hash      [32]byte
 )
 
-// HashTreeRoot computes a placeholder block root.
-// TODO: Implement proper SSZ hash tree root computation.
-func (b *BlindedBeaconBlock) HashTreeRoot() [32]byte {
-	// For now, use a hash of the block hash as a placeholder
-	return sha256.Sum256(b.Body.ExecutionPayloadHeader.BlockHash)
-}
+// proofTypeCount is the number of valid proof types, numbered from 0.
+const proofTypeCount = 8
 
 func kzgCommitmentsToVersionedHashes(blindedBody *BlindedBeaconBlockBody) [][]byte {
 	commitments := blindedBody.BlobKzgCommitments
@@ -165,6 +352,11 @@ func (s *Slot) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes a Slot as a quoted decimal string.
+func (s Slot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(formatQuotedUint64(uint64(s)))
+}
+
 // UnmarshalJSON parses a hex string with 0x prefix into a Root.
 func (r *Root) UnmarshalJSON(data []byte) error {
 	var str string
@@ -192,17 +384,97 @@ func decodeHexBytes(s string) ([]byte, error) {
 	return hex.DecodeString(s)
 }
 
+// Helper to decode a list of hex strings to bytes
+func decodeHexBytesList(list []string) ([][]byte, error) {
+	decoded := make([][]byte, len(list))
+	for i, item := range list {
+		b, err := decodeHexBytes(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		decoded[i] = b
+	}
+
+	return decoded, nil
+}
+
 // Helper to parse quoted uint64
 func parseQuotedUint64(s string) (uint64, error) {
 	return strconv.ParseUint(s, 10, 64)
 }
 
+// Helper to encode bytes as a 0x-prefixed hex string
+func encodeHexBytes(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// Helper to format a uint64 as a quoted decimal
+func formatQuotedUint64(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+// nonNil returns s, or an empty slice if s is nil, so it encodes as an empty JSON list.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+
+	return s
+}
+
+// requireField returns errMissingField for name if value is nil.
+func requireField[T any](name string, value *T) error {
+	if value == nil {
+		return fmt.Errorf("%s: %w", name, errMissingField)
+	}
+
+	return nil
+}
+
+// requireItems returns errMissingField for name if any item of list is null.
+func requireItems[T any](name string, list []*T) error {
+	for i, item := range list {
+		if item == nil {
+			return fmt.Errorf("%s[%d]: %w", name, i, errMissingField)
+		}
+	}
+
+	return nil
+}
+
+// parseQuotedUint256 parses a decimal uint256 into its 32-byte little-endian SSZ encoding.
+func parseQuotedUint256(s string) ([]byte, error) {
+	value, err := uint256.FromDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := value.Bytes32()
+	slices.Reverse(encoded[:])
+
+	return encoded[:], nil
+}
+
+// formatQuotedUint256 formats a 32-byte little-endian SSZ uint256 as a decimal.
+func formatQuotedUint256(b []byte) (string, error) {
+	if len(b) != 32 {
+		return "", fmt.Errorf("invalid uint256 length: got %d, want 32", len(b))
+	}
+
+	bigEndian := slices.Clone(b)
+	slices.Reverse(bigEndian)
+
+	return new(uint256.Int).SetBytes32(bigEndian).Dec(), nil
+}
+
 // UnmarshalJSON parses beacon API JSON format into BlindedBeaconBlock.
 func (b *BlindedBeaconBlock) UnmarshalJSON(data []byte) error {
 	type jsonBlindedBeaconBlock struct {
-		Slot       string                  `json:"slot"`
-		ParentRoot string                  `json:"parent_root"`
-		Body       *BlindedBeaconBlockBody `json:"body"`
+		Slot          Slot                    `json:"slot"`
+		ProposerIndex string                  `json:"proposer_index"`
+		ParentRoot    string                  `json:"parent_root"`
+		StateRoot     string                  `json:"state_root"`
+		Body          *BlindedBeaconBlockBody `json:"body"`
 	}
 
 	var jb jsonBlindedBeaconBlock
@@ -210,44 +482,213 @@ func (b *BlindedBeaconBlock) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	slot, err := parseQuotedUint64(jb.Slot)
+	var err error
+
+	b.Slot = jb.Slot
+	if b.ProposerIndex, err = parseQuotedUint64(jb.ProposerIndex); err != nil {
+		return fmt.Errorf("parse proposer_index: %w", err)
+	}
+	if b.ParentRoot, err = decodeHexBytes(jb.ParentRoot); err != nil {
+		return fmt.Errorf("decode parent_root: %w", err)
+	}
+	if b.StateRoot, err = decodeHexBytes(jb.StateRoot); err != nil {
+		return fmt.Errorf("decode state_root: %w", err)
+	}
+	if err := requireField("body", jb.Body); err != nil {
+		return err
+	}
+	b.Body = jb.Body
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into BeaconBlockHeader.
+func (h *BeaconBlockHeader) UnmarshalJSON(data []byte) error {
+	type jsonBeaconBlockHeader struct {
+		Slot          Slot   `json:"slot"`
+		ProposerIndex string `json:"proposer_index"`
+		ParentRoot    string `json:"parent_root"`
+		StateRoot     string `json:"state_root"`
+		BodyRoot      string `json:"body_root"`
+	}
+
+	var jh jsonBeaconBlockHeader
+	if err := json.Unmarshal(data, &jh); err != nil {
+		return err
+	}
+
+	var err error
+
+	h.Slot = jh.Slot
+	if h.ProposerIndex, err = parseQuotedUint64(jh.ProposerIndex); err != nil {
+		return fmt.Errorf("parse proposer_index: %w", err)
+	}
+	if h.ParentRoot, err = decodeHexBytes(jh.ParentRoot); err != nil {
+		return fmt.Errorf("decode parent_root: %w", err)
+	}
+	if h.StateRoot, err = decodeHexBytes(jh.StateRoot); err != nil {
+		return fmt.Errorf("decode state_root: %w", err)
+	}
+	if h.BodyRoot, err = decodeHexBytes(jh.BodyRoot); err != nil {
+		return fmt.Errorf("decode body_root: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into SignedBeaconBlockHeader.
+func (h *SignedBeaconBlockHeader) UnmarshalJSON(data []byte) error {
+	type jsonSignedBeaconBlockHeader struct {
+		Message   *BeaconBlockHeader `json:"message"`
+		Signature string             `json:"signature"`
+	}
+
+	var jh jsonSignedBeaconBlockHeader
+	if err := json.Unmarshal(data, &jh); err != nil {
+		return err
+	}
+
+	if err := requireField("message", jh.Message); err != nil {
+		return err
+	}
+
+	signature, err := decodeHexBytes(jh.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	h.Message = jh.Message
+	h.Signature = signature
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into SignedBlindedBeaconBlock.
+func (b *SignedBlindedBeaconBlock) UnmarshalJSON(data []byte) error {
+	type jsonSignedBlindedBeaconBlock struct {
+		Message   *BlindedBeaconBlock `json:"message"`
+		Signature string              `json:"signature"`
+	}
+
+	var jb jsonSignedBlindedBeaconBlock
+	if err := json.Unmarshal(data, &jb); err != nil {
+		return err
+	}
+
+	if err := requireField("message", jb.Message); err != nil {
+		return err
+	}
+
+	signature, err := decodeHexBytes(jb.Signature)
 	if err != nil {
-		return fmt.Errorf("parse slot: %w", err)
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	b.Message = jb.Message
+	b.Signature = signature
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into Genesis.
+func (g *Genesis) UnmarshalJSON(data []byte) error {
+	type jsonGenesis struct {
+		GenesisTime           string `json:"genesis_time"`
+		GenesisValidatorsRoot string `json:"genesis_validators_root"`
+		GenesisForkVersion    string `json:"genesis_fork_version"`
+	}
+
+	var jg jsonGenesis
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return err
+	}
+
+	var err error
+
+	if g.GenesisTime, err = parseQuotedUint64(jg.GenesisTime); err != nil {
+		return fmt.Errorf("parse genesis_time: %w", err)
+	}
+	if g.GenesisValidatorsRoot, err = decodeHexBytes(jg.GenesisValidatorsRoot); err != nil {
+		return fmt.Errorf("decode genesis_validators_root: %w", err)
+	}
+	if g.GenesisForkVersion, err = decodeHexBytes(jg.GenesisForkVersion); err != nil {
+		return fmt.Errorf("decode genesis_fork_version: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into Fork.
+func (f *Fork) UnmarshalJSON(data []byte) error {
+	type jsonFork struct {
+		PreviousVersion string `json:"previous_version"`
+		CurrentVersion  string `json:"current_version"`
+		Epoch           string `json:"epoch"`
+	}
+
+	var jf jsonFork
+	if err := json.Unmarshal(data, &jf); err != nil {
+		return err
+	}
+
+	var err error
+
+	if f.PreviousVersion, err = decodeHexBytes(jf.PreviousVersion); err != nil {
+		return fmt.Errorf("decode previous_version: %w", err)
+	}
+	if f.CurrentVersion, err = decodeHexBytes(jf.CurrentVersion); err != nil {
+		return fmt.Errorf("decode current_version: %w", err)
+	}
+	if f.Epoch, err = parseQuotedUint64(jf.Epoch); err != nil {
+		return fmt.Errorf("parse epoch: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into ValidatorData.
+func (v *ValidatorData) UnmarshalJSON(data []byte) error {
+	type jsonValidatorData struct {
+		Index string `json:"index"`
+	}
+
+	var jv jsonValidatorData
+	if err := json.Unmarshal(data, &jv); err != nil {
+		return err
 	}
-	b.Slot = Slot(slot)
 
-	parentRoot, err := decodeHexBytes(jb.ParentRoot)
+	index, err := parseQuotedUint64(jv.Index)
 	if err != nil {
-		return fmt.Errorf("decode parent_root: %w", err)
+		return fmt.Errorf("parse index: %w", err)
 	}
-	b.ParentRoot = parentRoot
-	b.Body = jb.Body
+	v.Index = index
 
 	return nil
 }
 
+// jsonExecutionPayloadHeader is the beacon API JSON format of ExecutionPayloadHeader.
+type jsonExecutionPayloadHeader struct {
+	ParentHash       string `json:"parent_hash"`
+	FeeRecipient     string `json:"fee_recipient"`
+	StateRoot        string `json:"state_root"`
+	ReceiptsRoot     string `json:"receipts_root"`
+	LogsBloom        string `json:"logs_bloom"`
+	PrevRandao       string `json:"prev_randao"`
+	BlockNumber      string `json:"block_number"`
+	GasLimit         string `json:"gas_limit"`
+	GasUsed          string `json:"gas_used"`
+	Timestamp        string `json:"timestamp"`
+	ExtraData        string `json:"extra_data"`
+	BaseFeePerGas    string `json:"base_fee_per_gas"`
+	BlockHash        string `json:"block_hash"`
+	TransactionsRoot string `json:"transactions_root"`
+	WithdrawalsRoot  string `json:"withdrawals_root"`
+	BlobGasUsed      string `json:"blob_gas_used"`
+	ExcessBlobGas    string `json:"excess_blob_gas"`
+}
+
 // UnmarshalJSON parses beacon API JSON format into ExecutionPayloadHeader.
 func (e *ExecutionPayloadHeader) UnmarshalJSON(data []byte) error {
-	type jsonExecutionPayloadHeader struct {
-		ParentHash       string `json:"parent_hash"`
-		FeeRecipient     string `json:"fee_recipient"`
-		StateRoot        string `json:"state_root"`
-		ReceiptsRoot     string `json:"receipts_root"`
-		LogsBloom        string `json:"logs_bloom"`
-		PrevRandao       string `json:"prev_randao"`
-		BlockNumber      string `json:"block_number"`
-		GasLimit         string `json:"gas_limit"`
-		GasUsed          string `json:"gas_used"`
-		Timestamp        string `json:"timestamp"`
-		ExtraData        string `json:"extra_data"`
-		BaseFeePerGas    string `json:"base_fee_per_gas"`
-		BlockHash        string `json:"block_hash"`
-		TransactionsRoot string `json:"transactions_root"`
-		WithdrawalsRoot  string `json:"withdrawals_root"`
-		BlobGasUsed      string `json:"blob_gas_used"`
-		ExcessBlobGas    string `json:"excess_blob_gas"`
-	}
-
 	var je jsonExecutionPayloadHeader
 	if err := json.Unmarshal(data, &je); err != nil {
 		return err
@@ -288,16 +729,9 @@ func (e *ExecutionPayloadHeader) UnmarshalJSON(data []byte) error {
 	if e.ExtraData, err = decodeHexBytes(je.ExtraData); err != nil {
 		return fmt.Errorf("decode extra_data: %w", err)
 	}
-	// BaseFeePerGas is a uint256 encoded as decimal string, we need to convert to 32-byte big-endian
-	baseFee, err := parseQuotedUint64(je.BaseFeePerGas)
-	if err != nil {
+	if e.BaseFeePerGas, err = parseQuotedUint256(je.BaseFeePerGas); err != nil {
 		return fmt.Errorf("parse base_fee_per_gas: %w", err)
 	}
-	e.BaseFeePerGas = make([]byte, 32)
-	// Store as little-endian (SSZ uses little-endian for uint256)
-	for i := 0; i < 8; i++ {
-		e.BaseFeePerGas[i] = byte(baseFee >> (8 * i))
-	}
 	if e.BlockHash, err = decodeHexBytes(je.BlockHash); err != nil {
 		return fmt.Errorf("decode block_hash: %w", err)
 	}
@@ -317,29 +751,769 @@ func (e *ExecutionPayloadHeader) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// UnmarshalJSON parses beacon API JSON format into BlindedBeaconBlockBody.
-func (b *BlindedBeaconBlockBody) UnmarshalJSON(data []byte) error {
-	type jsonBlindedBeaconBlockBody struct {
-		ExecutionPayloadHeader *ExecutionPayloadHeader `json:"execution_payload_header"`
-		BlobKzgCommitments     []string                `json:"blob_kzg_commitments"`
-		ExecutionRequests      *ExecutionRequests      `json:"execution_requests"`
+// MarshalJSON encodes ExecutionPayloadHeader in beacon API JSON format.
+func (e *ExecutionPayloadHeader) MarshalJSON() ([]byte, error) {
+	baseFeePerGas, err := formatQuotedUint256(e.BaseFeePerGas)
+	if err != nil {
+		return nil, fmt.Errorf("format base_fee_per_gas: %w", err)
 	}
 
-	var jb jsonBlindedBeaconBlockBody
-	if err := json.Unmarshal(data, &jb); err != nil {
+	return json.Marshal(&jsonExecutionPayloadHeader{
+		ParentHash:       encodeHexBytes(e.ParentHash),
+		FeeRecipient:     encodeHexBytes(e.FeeRecipient),
+		StateRoot:        encodeHexBytes(e.StateRoot),
+		ReceiptsRoot:     encodeHexBytes(e.ReceiptsRoot),
+		LogsBloom:        encodeHexBytes(e.LogsBloom),
+		PrevRandao:       encodeHexBytes(e.PrevRandao),
+		BlockNumber:      formatQuotedUint64(e.BlockNumber),
+		GasLimit:         formatQuotedUint64(e.GasLimit),
+		GasUsed:          formatQuotedUint64(e.GasUsed),
+		Timestamp:        formatQuotedUint64(e.Timestamp),
+		ExtraData:        encodeHexBytes(e.ExtraData),
+		BaseFeePerGas:    baseFeePerGas,
+		BlockHash:        encodeHexBytes(e.BlockHash),
+		TransactionsRoot: encodeHexBytes(e.TransactionsRoot),
+		WithdrawalsRoot:  encodeHexBytes(e.WithdrawalsRoot),
+		BlobGasUsed:      formatQuotedUint64(e.BlobGasUsed),
+		ExcessBlobGas:    formatQuotedUint64(e.ExcessBlobGas),
+	})
+}
+
+// jsonExecutionRequests is the beacon API JSON format of ExecutionRequests.
+type jsonExecutionRequests struct {
+	Deposits       []*Deposit       `json:"deposits"`
+	Withdrawals    []*Withdrawal    `json:"withdrawals"`
+	Consolidations []*Consolidation `json:"consolidations"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into ExecutionRequests.
+func (e *ExecutionRequests) UnmarshalJSON(data []byte) error {
+	var je jsonExecutionRequests
+	if err := json.Unmarshal(data, &je); err != nil {
 		return err
 	}
 
-	b.ExecutionPayloadHeader = jb.ExecutionPayloadHeader
-	b.ExecutionRequests = jb.ExecutionRequests
-
-	b.BlobKzgCommitments = make([][]byte, len(jb.BlobKzgCommitments))
-	for i, c := range jb.BlobKzgCommitments {
-		decoded, err := decodeHexBytes(c)
+	for _, err := range []error{
+		requireItems("deposits", je.Deposits),
+		requireItems("withdrawals", je.Withdrawals),
+		requireItems("consolidations", je.Consolidations),
+	} {
 		if err != nil {
-			return fmt.Errorf("decode blob_kzg_commitments[%d]: %w", i, err)
+			return err
 		}
-		b.BlobKzgCommitments[i] = decoded
+	}
+
+	e.Deposits = je.Deposits
+	e.Withdrawals = je.Withdrawals
+	e.Consolidations = je.Consolidations
+
+	return nil
+}
+
+// MarshalJSON encodes ExecutionRequests in beacon API JSON format, with empty lists rather than null.
+func (e *ExecutionRequests) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonExecutionRequests{
+		Deposits:       nonNil(e.Deposits),
+		Withdrawals:    nonNil(e.Withdrawals),
+		Consolidations: nonNil(e.Consolidations),
+	})
+}
+
+// jsonDeposit is the beacon API JSON format of Deposit.
+type jsonDeposit struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+	Index                 string `json:"index"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into Deposit.
+func (d *Deposit) UnmarshalJSON(data []byte) error {
+	var jd jsonDeposit
+	if err := json.Unmarshal(data, &jd); err != nil {
+		return err
+	}
+
+	var err error
+
+	if d.Pubkey, err = decodeHexBytes(jd.Pubkey); err != nil {
+		return fmt.Errorf("decode pubkey: %w", err)
+	}
+	if d.WithdrawalCredentials, err = decodeHexBytes(jd.WithdrawalCredentials); err != nil {
+		return fmt.Errorf("decode withdrawal_credentials: %w", err)
+	}
+	if d.Amount, err = parseQuotedUint64(jd.Amount); err != nil {
+		return fmt.Errorf("parse amount: %w", err)
+	}
+	if d.Signature, err = decodeHexBytes(jd.Signature); err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	if d.Index, err = parseQuotedUint64(jd.Index); err != nil {
+		return fmt.Errorf("parse index: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes Deposit in beacon API JSON format.
+func (d *Deposit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonDeposit{
+		Pubkey:                encodeHexBytes(d.Pubkey),
+		WithdrawalCredentials: encodeHexBytes(d.WithdrawalCredentials),
+		Amount:                formatQuotedUint64(d.Amount),
+		Signature:             encodeHexBytes(d.Signature),
+		Index:                 formatQuotedUint64(d.Index),
+	})
+}
+
+// jsonWithdrawal is the beacon API JSON format of Withdrawal.
+type jsonWithdrawal struct {
+	SourceAddress   string `json:"source_address"`
+	ValidatorPubkey string `json:"validator_pubkey"`
+	Amount          string `json:"amount"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into Withdrawal.
+func (w *Withdrawal) UnmarshalJSON(data []byte) error {
+	var jw jsonWithdrawal
+	if err := json.Unmarshal(data, &jw); err != nil {
+		return err
+	}
+
+	var err error
+
+	if w.SourceAddress, err = decodeHexBytes(jw.SourceAddress); err != nil {
+		return fmt.Errorf("decode source_address: %w", err)
+	}
+	if w.ValidatorPubkey, err = decodeHexBytes(jw.ValidatorPubkey); err != nil {
+		return fmt.Errorf("decode validator_pubkey: %w", err)
+	}
+	if w.Amount, err = parseQuotedUint64(jw.Amount); err != nil {
+		return fmt.Errorf("parse amount: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes Withdrawal in beacon API JSON format.
+func (w *Withdrawal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonWithdrawal{
+		SourceAddress:   encodeHexBytes(w.SourceAddress),
+		ValidatorPubkey: encodeHexBytes(w.ValidatorPubkey),
+		Amount:          formatQuotedUint64(w.Amount),
+	})
+}
+
+// jsonConsolidation is the beacon API JSON format of Consolidation.
+type jsonConsolidation struct {
+	SourceAddress string `json:"source_address"`
+	SourcePubkey  string `json:"source_pubkey"`
+	TargetPubkey  string `json:"target_pubkey"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into Consolidation.
+func (c *Consolidation) UnmarshalJSON(data []byte) error {
+	var jc jsonConsolidation
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return err
+	}
+
+	var err error
+
+	if c.SourceAddress, err = decodeHexBytes(jc.SourceAddress); err != nil {
+		return fmt.Errorf("decode source_address: %w", err)
+	}
+	if c.SourcePubkey, err = decodeHexBytes(jc.SourcePubkey); err != nil {
+		return fmt.Errorf("decode source_pubkey: %w", err)
+	}
+	if c.TargetPubkey, err = decodeHexBytes(jc.TargetPubkey); err != nil {
+		return fmt.Errorf("decode target_pubkey: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes Consolidation in beacon API JSON format.
+func (c *Consolidation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonConsolidation{
+		SourceAddress: encodeHexBytes(c.SourceAddress),
+		SourcePubkey:  encodeHexBytes(c.SourcePubkey),
+		TargetPubkey:  encodeHexBytes(c.TargetPubkey),
+	})
+}
+
+// jsonExecutionProof is the beacon API JSON format of ExecutionProof.
+type jsonExecutionProof struct {
+	ProofData   string       `json:"proof_data"`
+	ProofType   string       `json:"proof_type"`
+	PublicInput *PublicInput `json:"public_input"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into ExecutionProof.
+func (e *ExecutionProof) UnmarshalJSON(data []byte) error {
+	var je jsonExecutionProof
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	var err error
+
+	if e.ProofData, err = decodeHexBytes(je.ProofData); err != nil {
+		return fmt.Errorf("decode proof_data: %w", err)
+	}
+
+	proofType, err := strconv.ParseUint(je.ProofType, 10, 8)
+	if err != nil {
+		return fmt.Errorf("parse proof_type: %w", err)
+	}
+	e.ProofType = ProofType(proofType)
+
+	if err := requireField("public_input", je.PublicInput); err != nil {
+		return err
+	}
+	e.PublicInput = je.PublicInput
+
+	return nil
+}
+
+// MarshalJSON encodes ExecutionProof in beacon API JSON format.
+func (e *ExecutionProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonExecutionProof{
+		ProofData:   encodeHexBytes(e.ProofData),
+		ProofType:   formatQuotedUint64(uint64(e.ProofType)),
+		PublicInput: e.PublicInput,
+	})
+}
+
+// jsonSignedExecutionProof is the beacon API JSON format of SignedExecutionProof.
+type jsonSignedExecutionProof struct {
+	Message        *ExecutionProof `json:"message"`
+	ValidatorIndex string          `json:"validator_index"`
+	Signature      string          `json:"signature"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into SignedExecutionProof.
+func (s *SignedExecutionProof) UnmarshalJSON(data []byte) error {
+	var js jsonSignedExecutionProof
+	if err := json.Unmarshal(data, &js); err != nil {
+		return err
+	}
+
+	if err := requireField("message", js.Message); err != nil {
+		return err
+	}
+
+	var err error
+
+	s.Message = js.Message
+
+	if s.ValidatorIndex, err = parseQuotedUint64(js.ValidatorIndex); err != nil {
+		return fmt.Errorf("parse validator_index: %w", err)
+	}
+	if s.Signature, err = decodeHexBytes(js.Signature); err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes SignedExecutionProof in beacon API JSON format.
+func (s *SignedExecutionProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonSignedExecutionProof{
+		Message:        s.Message,
+		ValidatorIndex: formatQuotedUint64(s.ValidatorIndex),
+		Signature:      encodeHexBytes(s.Signature),
+	})
+}
+
+// jsonPublicInput is the beacon API JSON format of PublicInput.
+type jsonPublicInput struct {
+	NewPayloadRequestRoot string `json:"new_payload_request_root"`
+}
+
+// UnmarshalJSON parses beacon API JSON format into PublicInput.
+func (p *PublicInput) UnmarshalJSON(data []byte) error {
+	var jp jsonPublicInput
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+
+	var err error
+
+	if p.NewPayloadRequestRoot, err = decodeHexBytes(jp.NewPayloadRequestRoot); err != nil {
+		return fmt.Errorf("decode new_payload_request_root: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes PublicInput in beacon API JSON format.
+func (p *PublicInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonPublicInput{
+		NewPayloadRequestRoot: encodeHexBytes(p.NewPayloadRequestRoot),
+	})
+}
+
+// UnmarshalJSON parses beacon API JSON format into BlindedBeaconBlockBody.
+func (b *BlindedBeaconBlockBody) UnmarshalJSON(data []byte) error {
+	type jsonBlindedBeaconBlockBody struct {
+		RandaoReveal           string                        `json:"randao_reveal"`
+		Eth1Data               *Eth1Data                     `json:"eth1_data"`
+		Graffiti               string                        `json:"graffiti"`
+		ProposerSlashings      []*ProposerSlashing           `json:"proposer_slashings"`
+		AttesterSlashings      []*AttesterSlashing           `json:"attester_slashings"`
+		Attestations           []*Attestation                `json:"attestations"`
+		Deposits               []*Eth1Deposit                `json:"deposits"`
+		VoluntaryExits         []*SignedVoluntaryExit        `json:"voluntary_exits"`
+		SyncAggregate          *SyncAggregate                `json:"sync_aggregate"`
+		ExecutionPayloadHeader *ExecutionPayloadHeader       `json:"execution_payload_header"`
+		BLSToExecutionChanges  []*SignedBLSToExecutionChange `json:"bls_to_execution_changes"`
+		BlobKzgCommitments     []string                      `json:"blob_kzg_commitments"`
+		ExecutionRequests      *ExecutionRequests            `json:"execution_requests"`
+	}
+
+	var jb jsonBlindedBeaconBlockBody
+	if err := json.Unmarshal(data, &jb); err != nil {
+		return err
+	}
+
+	var err error
+
+	if b.RandaoReveal, err = decodeHexBytes(jb.RandaoReveal); err != nil {
+		return fmt.Errorf("decode randao_reveal: %w", err)
+	}
+	if b.Graffiti, err = decodeHexBytes(jb.Graffiti); err != nil {
+		return fmt.Errorf("decode graffiti: %w", err)
+	}
+	if b.BlobKzgCommitments, err = decodeHexBytesList(jb.BlobKzgCommitments); err != nil {
+		return fmt.Errorf("decode blob_kzg_commitments: %w", err)
+	}
+
+	for _, err := range []error{
+		requireField("eth1_data", jb.Eth1Data),
+		requireItems("proposer_slashings", jb.ProposerSlashings),
+		requireItems("attester_slashings", jb.AttesterSlashings),
+		requireItems("attestations", jb.Attestations),
+		requireItems("deposits", jb.Deposits),
+		requireItems("voluntary_exits", jb.VoluntaryExits),
+		requireField("sync_aggregate", jb.SyncAggregate),
+		requireField("execution_payload_header", jb.ExecutionPayloadHeader),
+		requireItems("bls_to_execution_changes", jb.BLSToExecutionChanges),
+		requireField("execution_requests", jb.ExecutionRequests),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	b.Eth1Data = jb.Eth1Data
+	b.ProposerSlashings = jb.ProposerSlashings
+	b.AttesterSlashings = jb.AttesterSlashings
+	b.Attestations = jb.Attestations
+	b.Deposits = jb.Deposits
+	b.VoluntaryExits = jb.VoluntaryExits
+	b.SyncAggregate = jb.SyncAggregate
+	b.ExecutionPayloadHeader = jb.ExecutionPayloadHeader
+	b.BLSToExecutionChanges = jb.BLSToExecutionChanges
+	b.ExecutionRequests = jb.ExecutionRequests
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into Eth1Data.
+func (e *Eth1Data) UnmarshalJSON(data []byte) error {
+	type jsonEth1Data struct {
+		DepositRoot  string `json:"deposit_root"`
+		DepositCount string `json:"deposit_count"`
+		BlockHash    string `json:"block_hash"`
+	}
+
+	var je jsonEth1Data
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	var err error
+
+	if e.DepositRoot, err = decodeHexBytes(je.DepositRoot); err != nil {
+		return fmt.Errorf("decode deposit_root: %w", err)
+	}
+	if e.DepositCount, err = parseQuotedUint64(je.DepositCount); err != nil {
+		return fmt.Errorf("parse deposit_count: %w", err)
+	}
+	if e.BlockHash, err = decodeHexBytes(je.BlockHash); err != nil {
+		return fmt.Errorf("decode block_hash: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into ProposerSlashing.
+func (p *ProposerSlashing) UnmarshalJSON(data []byte) error {
+	type jsonProposerSlashing struct {
+		SignedHeader1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+		SignedHeader2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+	}
+
+	var jp jsonProposerSlashing
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+
+	if err := requireField("signed_header_1", jp.SignedHeader1); err != nil {
+		return err
+	}
+	if err := requireField("signed_header_2", jp.SignedHeader2); err != nil {
+		return err
+	}
+
+	p.SignedHeader1 = jp.SignedHeader1
+	p.SignedHeader2 = jp.SignedHeader2
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into AttesterSlashing.
+func (a *AttesterSlashing) UnmarshalJSON(data []byte) error {
+	type jsonAttesterSlashing struct {
+		Attestation1 *IndexedAttestation `json:"attestation_1"`
+		Attestation2 *IndexedAttestation `json:"attestation_2"`
+	}
+
+	var ja jsonAttesterSlashing
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	if err := requireField("attestation_1", ja.Attestation1); err != nil {
+		return err
+	}
+	if err := requireField("attestation_2", ja.Attestation2); err != nil {
+		return err
+	}
+
+	a.Attestation1 = ja.Attestation1
+	a.Attestation2 = ja.Attestation2
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into IndexedAttestation.
+func (a *IndexedAttestation) UnmarshalJSON(data []byte) error {
+	type jsonIndexedAttestation struct {
+		AttestingIndices []string         `json:"attesting_indices"`
+		Data             *AttestationData `json:"data"`
+		Signature        string           `json:"signature"`
+	}
+
+	var ja jsonIndexedAttestation
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	if err := requireField("data", ja.Data); err != nil {
+		return err
+	}
+
+	a.AttestingIndices = make([]uint64, len(ja.AttestingIndices))
+	for i, index := range ja.AttestingIndices {
+		parsed, err := parseQuotedUint64(index)
+		if err != nil {
+			return fmt.Errorf("parse attesting_indices[%d]: %w", i, err)
+		}
+		a.AttestingIndices[i] = parsed
+	}
+
+	signature, err := decodeHexBytes(ja.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	a.Data = ja.Data
+	a.Signature = signature
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into Attestation.
+func (a *Attestation) UnmarshalJSON(data []byte) error {
+	type jsonAttestation struct {
+		AggregationBits string           `json:"aggregation_bits"`
+		Data            *AttestationData `json:"data"`
+		Signature       string           `json:"signature"`
+		CommitteeBits   string           `json:"committee_bits"`
+	}
+
+	var ja jsonAttestation
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	if err := requireField("data", ja.Data); err != nil {
+		return err
+	}
+
+	var err error
+
+	if a.AggregationBits, err = decodeHexBytes(ja.AggregationBits); err != nil {
+		return fmt.Errorf("decode aggregation_bits: %w", err)
+	}
+	if a.Signature, err = decodeHexBytes(ja.Signature); err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	if a.CommitteeBits, err = decodeHexBytes(ja.CommitteeBits); err != nil {
+		return fmt.Errorf("decode committee_bits: %w", err)
+	}
+	a.Data = ja.Data
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into AttestationData.
+func (a *AttestationData) UnmarshalJSON(data []byte) error {
+	type jsonAttestationData struct {
+		Slot            Slot        `json:"slot"`
+		Index           string      `json:"index"`
+		BeaconBlockRoot string      `json:"beacon_block_root"`
+		Source          *Checkpoint `json:"source"`
+		Target          *Checkpoint `json:"target"`
+	}
+
+	var ja jsonAttestationData
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	var err error
+
+	a.Slot = ja.Slot
+	if a.Index, err = parseQuotedUint64(ja.Index); err != nil {
+		return fmt.Errorf("parse index: %w", err)
+	}
+	if a.BeaconBlockRoot, err = decodeHexBytes(ja.BeaconBlockRoot); err != nil {
+		return fmt.Errorf("decode beacon_block_root: %w", err)
+	}
+	if err := requireField("source", ja.Source); err != nil {
+		return err
+	}
+	if err := requireField("target", ja.Target); err != nil {
+		return err
+	}
+	a.Source = ja.Source
+	a.Target = ja.Target
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into Checkpoint.
+func (c *Checkpoint) UnmarshalJSON(data []byte) error {
+	type jsonCheckpoint struct {
+		Epoch string `json:"epoch"`
+		Root  string `json:"root"`
+	}
+
+	var jc jsonCheckpoint
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return err
+	}
+
+	var err error
+
+	if c.Epoch, err = parseQuotedUint64(jc.Epoch); err != nil {
+		return fmt.Errorf("parse epoch: %w", err)
+	}
+	if c.Root, err = decodeHexBytes(jc.Root); err != nil {
+		return fmt.Errorf("decode root: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into Eth1Deposit.
+func (d *Eth1Deposit) UnmarshalJSON(data []byte) error {
+	type jsonEth1Deposit struct {
+		Proof []string     `json:"proof"`
+		Data  *DepositData `json:"data"`
+	}
+
+	var jd jsonEth1Deposit
+	if err := json.Unmarshal(data, &jd); err != nil {
+		return err
+	}
+
+	if err := requireField("data", jd.Data); err != nil {
+		return err
+	}
+
+	proof, err := decodeHexBytesList(jd.Proof)
+	if err != nil {
+		return fmt.Errorf("decode proof: %w", err)
+	}
+
+	d.Proof = proof
+	d.Data = jd.Data
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into DepositData.
+func (d *DepositData) UnmarshalJSON(data []byte) error {
+	type jsonDepositData struct {
+		Pubkey                string `json:"pubkey"`
+		WithdrawalCredentials string `json:"withdrawal_credentials"`
+		Amount                string `json:"amount"`
+		Signature             string `json:"signature"`
+	}
+
+	var jd jsonDepositData
+	if err := json.Unmarshal(data, &jd); err != nil {
+		return err
+	}
+
+	var err error
+
+	if d.Pubkey, err = decodeHexBytes(jd.Pubkey); err != nil {
+		return fmt.Errorf("decode pubkey: %w", err)
+	}
+	if d.WithdrawalCredentials, err = decodeHexBytes(jd.WithdrawalCredentials); err != nil {
+		return fmt.Errorf("decode withdrawal_credentials: %w", err)
+	}
+	if d.Amount, err = parseQuotedUint64(jd.Amount); err != nil {
+		return fmt.Errorf("parse amount: %w", err)
+	}
+	if d.Signature, err = decodeHexBytes(jd.Signature); err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into SignedVoluntaryExit.
+func (e *SignedVoluntaryExit) UnmarshalJSON(data []byte) error {
+	type jsonSignedVoluntaryExit struct {
+		Message   *VoluntaryExit `json:"message"`
+		Signature string         `json:"signature"`
+	}
+
+	var je jsonSignedVoluntaryExit
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	if err := requireField("message", je.Message); err != nil {
+		return err
+	}
+
+	signature, err := decodeHexBytes(je.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	e.Message = je.Message
+	e.Signature = signature
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into VoluntaryExit.
+func (e *VoluntaryExit) UnmarshalJSON(data []byte) error {
+	type jsonVoluntaryExit struct {
+		Epoch          string `json:"epoch"`
+		ValidatorIndex string `json:"validator_index"`
+	}
+
+	var je jsonVoluntaryExit
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	var err error
+
+	if e.Epoch, err = parseQuotedUint64(je.Epoch); err != nil {
+		return fmt.Errorf("parse epoch: %w", err)
+	}
+	if e.ValidatorIndex, err = parseQuotedUint64(je.ValidatorIndex); err != nil {
+		return fmt.Errorf("parse validator_index: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into SyncAggregate.
+func (s *SyncAggregate) UnmarshalJSON(data []byte) error {
+	type jsonSyncAggregate struct {
+		SyncCommitteeBits      string `json:"sync_committee_bits"`
+		SyncCommitteeSignature string `json:"sync_committee_signature"`
+	}
+
+	var js jsonSyncAggregate
+	if err := json.Unmarshal(data, &js); err != nil {
+		return err
+	}
+
+	var err error
+
+	if s.SyncCommitteeBits, err = decodeHexBytes(js.SyncCommitteeBits); err != nil {
+		return fmt.Errorf("decode sync_committee_bits: %w", err)
+	}
+	if s.SyncCommitteeSignature, err = decodeHexBytes(js.SyncCommitteeSignature); err != nil {
+		return fmt.Errorf("decode sync_committee_signature: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into SignedBLSToExecutionChange.
+func (c *SignedBLSToExecutionChange) UnmarshalJSON(data []byte) error {
+	type jsonSignedBLSToExecutionChange struct {
+		Message   *BLSToExecutionChange `json:"message"`
+		Signature string                `json:"signature"`
+	}
+
+	var jc jsonSignedBLSToExecutionChange
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return err
+	}
+
+	if err := requireField("message", jc.Message); err != nil {
+		return err
+	}
+
+	signature, err := decodeHexBytes(jc.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	c.Message = jc.Message
+	c.Signature = signature
+
+	return nil
+}
+
+// UnmarshalJSON parses beacon API JSON format into BLSToExecutionChange.
+func (c *BLSToExecutionChange) UnmarshalJSON(data []byte) error {
+	type jsonBLSToExecutionChange struct {
+		ValidatorIndex     string `json:"validator_index"`
+		FromBLSPubkey      string `json:"from_bls_pubkey"`
+		ToExecutionAddress string `json:"to_execution_address"`
+	}
+
+	var jc jsonBLSToExecutionChange
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return err
+	}
+
+	var err error
+
+	if c.ValidatorIndex, err = parseQuotedUint64(jc.ValidatorIndex); err != nil {
+		return fmt.Errorf("parse validator_index: %w", err)
+	}
+	if c.FromBLSPubkey, err = decodeHexBytes(jc.FromBLSPubkey); err != nil {
+		return fmt.Errorf("decode from_bls_pubkey: %w", err)
+	}
+	if c.ToExecutionAddress, err = decodeHexBytes(jc.ToExecutionAddress); err != nil {
+		return fmt.Errorf("decode to_execution_address: %w", err)
 	}
 
 	return nil
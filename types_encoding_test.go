@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/snappy"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"gopkg.in/yaml.v3"
+)
+
+// sszObject is implemented by the generated SSZ code of every container.
+type sszObject interface {
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+}
+
+// sszStaticTypes are the containers checked against ssz_static vectors, by spec name.
+var sszStaticTypes = map[string]func() sszObject{
+	"BlindedBeaconBlock":     func() sszObject { return new(BlindedBeaconBlock) },
+	"BlindedBeaconBlockBody": func() sszObject { return new(BlindedBeaconBlockBody) },
+	"ExecutionPayloadHeader": func() sszObject { return new(ExecutionPayloadHeader) },
+	"ExecutionRequests":      func() sszObject { return new(ExecutionRequests) },
+	"DepositRequest":         func() sszObject { return new(Deposit) },
+	"WithdrawalRequest":      func() sszObject { return new(Withdrawal) },
+	"ConsolidationRequest":   func() sszObject { return new(Consolidation) },
+}
+
+// TestSSZStatic decodes ssz_static vectors, in the consensus spec tests layout
+// (<type>/<suite>/<case>/serialized.ssz_snappy and roots.yaml), and checks that
+// they encode back to the same bytes and hash to the expected root.
+//
+// The vectors in testdata were generated with go-eth2-client. Set CONSENSUS_SPEC_TESTS
+// to an extracted consensus-spec-tests release to also run the Electra mainnet vectors,
+// which cover every type but the blinded block ones.
+func TestSSZStatic(t *testing.T) {
+	dirs := []string{filepath.Join("testdata", "ssz_static")}
+	if specTests := os.Getenv("CONSENSUS_SPEC_TESTS"); specTests != "" {
+		dirs = append(dirs, filepath.Join(specTests, "tests", "mainnet", "electra", "ssz_static"))
+	}
+
+	for _, dir := range dirs {
+		for name, newObject := range sszStaticTypes {
+			cases, err := filepath.Glob(filepath.Join(dir, name, "*", "case_*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(cases) == 0 && dir == dirs[0] {
+				t.Errorf("no %s vector in %s", name, dir)
+			}
+
+			for _, caseDir := range cases {
+				rel, _ := filepath.Rel(dir, caseDir)
+				t.Run(rel, func(t *testing.T) {
+					checkSSZStaticCase(t, caseDir, newObject())
+				})
+			}
+		}
+	}
+}
+
+// checkSSZStaticCase checks object against the ssz_static case in dir.
+func checkSSZStaticCase(t *testing.T, dir string, object sszObject) {
+	compressed, err := os.ReadFile(filepath.Join(dir, "serialized.ssz_snappy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialized, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+
+	rootsYAML, err := os.ReadFile(filepath.Join(dir, "roots.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var roots struct {
+		Root string `yaml:"root"`
+	}
+	if err := yaml.Unmarshal(rootsYAML, &roots); err != nil {
+		t.Fatalf("unmarshal roots: %v", err)
+	}
+
+	if err := object.UnmarshalSSZ(serialized); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	encoded, err := object.MarshalSSZ()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	if !bytes.Equal(encoded, serialized) {
+		t.Errorf("serialization mismatch:\ngot  %#x\nwant %#x", encoded, serialized)
+	}
+
+	root, err := object.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("hash tree root: %v", err)
+	}
+
+	assertBytes(t, "root", root[:], roots.Root)
+}
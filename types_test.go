@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// electraBlockFixture describes a testdata block: Electra devnet blocks from go-ethereum's
+// beacon/types testdata, with their execution payload replaced by its header. The expected
+// roots were computed from the full blocks with go-eth2-client and zrnt.
+type electraBlockFixture struct {
+	file                  string
+	slot                  Slot
+	proposerIndex         uint64
+	blockNumber           uint64
+	blockHash             string
+	blockRoot             string
+	versionedHashes       int
+	requests              *ExecutionRequests
+	newPayloadRequestRoot string
+}
+
+var electraBlockFixtures = []electraBlockFixture{
+	{
+		file:            "electra_blinded_block_deposits.json",
+		slot:            151016,
+		proposerIndex:   27017,
+		blockNumber:     140858,
+		blockHash:       "0x1f2637170986346c7993d5adbadbebbf4c9ed89c6a4d2dff653db99c8c168076",
+		blockRoot:       "0x7f52decd851222f7ff65908495c82bec5077dae8997fcbb8ca3b2289133fa080",
+		versionedHashes: 9,
+		requests: &ExecutionRequests{
+			Deposits: []*Deposit{{
+				Pubkey:                mustDecodeHex("0xa3dc91086418a5680fe3037dba62dda3de79dd22bb41036719c3771f140b419586ae7d9bdf3b10d88850909d4556b19b"),
+				WithdrawalCredentials: mustDecodeHex("0x010000000000000000000000bf3da697ab02552a5da95f267075cbe495d1ecb3"),
+				Amount:                32000000000,
+				Signature:             mustDecodeHex("0x896bccd536b4a30c4c3ce5877544574c624dff09f100abcb2df38df7c797069aed3213f1320fe77e4cf89907fb23fe4601a9008fdbac478412f8d6a5eb4c53b12c3848c29ced5ded2a7e3fbeb1e1dc4f58a563d761f7ea80c06088126e0dd9ea"),
+				Index:                 10011,
+			}},
+		},
+		newPayloadRequestRoot: "0xe6e91186a1e1f8ba2271c5f541f860b34143707583055dda4d969ea3205cb3f9",
+	},
+	{
+		file:            "electra_blinded_block_withdrawals.json",
+		slot:            151850,
+		proposerIndex:   38060,
+		blockNumber:     141654,
+		blockHash:       "0xf6730485a38be5ada3e110990a2c7adaabd2e8d4a49782134f1a8bfbc246a5d7",
+		blockRoot:       "0x8e62d34cce434ef17ee8f9cd452a964e7f337aa0e4de8f11688bfcaf2442d27e",
+		versionedHashes: 9,
+		requests: &ExecutionRequests{
+			Withdrawals: []*Withdrawal{{
+				SourceAddress:   mustDecodeHex("0x4b6c4667921a132ee6ed26a2de7654adae481e4d"),
+				ValidatorPubkey: mustDecodeHex("0x82586e0c10f84614733e5752428cbbe809e3699d9a3bc5bc604e81169e6a59304352237e39c8e6644acae5f595f1a864"),
+				Amount:          20000000,
+			}},
+		},
+		newPayloadRequestRoot: "0xbce03beff0ad18eadf39b4ffbbcf057da206f1dd17f1345a289dbcc269df7b4d",
+	},
+	{
+		file:            "electra_blinded_block_consolidations.json",
+		slot:            151717,
+		proposerIndex:   20165,
+		blockNumber:     141529,
+		blockHash:       "0xc8807f7a1f96b0a073ff27065776dd21eff6b7e64079c60bffd33f690efbb330",
+		blockRoot:       "0x702171768db97dfb4d7d7b47f457a692b1300e0a5ef664e4382b7170e237da4a",
+		versionedHashes: 0,
+		requests: &ExecutionRequests{
+			Consolidations: []*Consolidation{{
+				SourceAddress: mustDecodeHex("0xb57a360b34e22c598a9b0da37c5b9a7825da4db6"),
+				SourcePubkey:  mustDecodeHex("0xaa01b02b16b7a56850cc9b7e1275e8d49e16fc12bd30b4bdf2ef68b5543822b2466101cb541b8815b5ca1721120e4f9d"),
+				TargetPubkey:  mustDecodeHex("0xa3dc91086418a5680fe3037dba62dda3de79dd22bb41036719c3771f140b419586ae7d9bdf3b10d88850909d4556b19b"),
+			}},
+		},
+		newPayloadRequestRoot: "0xb178f7d74ce7c46473ce258c81a5c383f6bc191043e1455cac0db13dc10afcce",
+	},
+}
+
+func TestElectraBlockFixtures(t *testing.T) {
+	for _, fixture := range electraBlockFixtures {
+		t.Run(fixture.file, func(t *testing.T) {
+			block := readBlockFixture(t, fixture.file)
+
+			if block.Slot != fixture.slot {
+				t.Errorf("slot: got %d, want %d", block.Slot, fixture.slot)
+			}
+
+			if block.ProposerIndex != fixture.proposerIndex {
+				t.Errorf("proposer index: got %d, want %d", block.ProposerIndex, fixture.proposerIndex)
+			}
+
+			header := block.Body.ExecutionPayloadHeader
+			if header.BlockNumber != fixture.blockNumber {
+				t.Errorf("block number: got %d, want %d", header.BlockNumber, fixture.blockNumber)
+			}
+
+			assertBytes(t, "block hash", header.BlockHash, fixture.blockHash)
+
+			// Blinding preserves the block root
+			root, err := block.HashTreeRoot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertBytes(t, "block root", root[:], fixture.blockRoot)
+
+			assertExecutionRequests(t, block.Body.ExecutionRequests, fixture.requests)
+
+			newPayloadRequestHeader, newPayloadRequestRoot, err := buildNewPayloadRequestHeader(block)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := len(newPayloadRequestHeader.VersionedHashes); got != fixture.versionedHashes {
+				t.Errorf("versioned hashes: got %d, want %d", got, fixture.versionedHashes)
+			}
+
+			for _, versionedHash := range newPayloadRequestHeader.VersionedHashes {
+				if versionedHash[0] != 0x01 {
+					t.Errorf("versioned hash %#x: want version 0x01", versionedHash)
+				}
+			}
+
+			assertBytes(t, "new payload request root", newPayloadRequestRoot[:], fixture.newPayloadRequestRoot)
+		})
+	}
+}
+
+// readBlockFixture decodes the JSON blinded block in testdata/file.
+func readBlockFixture(t *testing.T, file string) *BlindedBeaconBlock {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := new(BlindedBeaconBlock)
+	if err := json.Unmarshal(data, block); err != nil {
+		t.Fatalf("unmarshal %s: %v", file, err)
+	}
+
+	return block
+}
+
+// assertExecutionRequests fails the test if got and want hold different requests.
+func assertExecutionRequests(t *testing.T, got, want *ExecutionRequests) {
+	t.Helper()
+
+	if len(got.Deposits) != len(want.Deposits) || len(got.Withdrawals) != len(want.Withdrawals) || len(got.Consolidations) != len(want.Consolidations) {
+		t.Fatalf("requests: got %d deposits, %d withdrawals and %d consolidations, want %d, %d and %d",
+			len(got.Deposits), len(got.Withdrawals), len(got.Consolidations),
+			len(want.Deposits), len(want.Withdrawals), len(want.Consolidations))
+	}
+
+	for i, deposit := range want.Deposits {
+		gotDeposit := got.Deposits[i]
+		if !bytes.Equal(gotDeposit.Pubkey, deposit.Pubkey) ||
+			!bytes.Equal(gotDeposit.WithdrawalCredentials, deposit.WithdrawalCredentials) ||
+			gotDeposit.Amount != deposit.Amount ||
+			!bytes.Equal(gotDeposit.Signature, deposit.Signature) ||
+			gotDeposit.Index != deposit.Index {
+			t.Errorf("deposit %d: got %+v, want %+v", i, gotDeposit, deposit)
+		}
+	}
+
+	for i, withdrawal := range want.Withdrawals {
+		gotWithdrawal := got.Withdrawals[i]
+		if !bytes.Equal(gotWithdrawal.SourceAddress, withdrawal.SourceAddress) ||
+			!bytes.Equal(gotWithdrawal.ValidatorPubkey, withdrawal.ValidatorPubkey) ||
+			gotWithdrawal.Amount != withdrawal.Amount {
+			t.Errorf("withdrawal %d: got %+v, want %+v", i, gotWithdrawal, withdrawal)
+		}
+	}
+
+	for i, consolidation := range want.Consolidations {
+		gotConsolidation := got.Consolidations[i]
+		if !bytes.Equal(gotConsolidation.SourceAddress, consolidation.SourceAddress) ||
+			!bytes.Equal(gotConsolidation.SourcePubkey, consolidation.SourcePubkey) ||
+			!bytes.Equal(gotConsolidation.TargetPubkey, consolidation.TargetPubkey) {
+			t.Errorf("consolidation %d: got %+v, want %+v", i, gotConsolidation, consolidation)
+		}
+	}
+}
+
+// assertBytes fails the test if got is not the 0x-prefixed hex string want.
+func assertBytes(t *testing.T, name string, got []byte, want string) {
+	t.Helper()
+
+	if !bytes.Equal(got, mustDecodeHex(want)) {
+		t.Errorf("%s: got %#x, want %s", name, got, want)
+	}
+}
+
+// mustDecodeHex decodes a 0x-prefixed hex string, panicking if it is invalid.
+func mustDecodeHex(s string) []byte {
+	decoded, err := decodeHexBytes(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return decoded
+}
+
+func TestProofJSONRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		file  string
+		value json.Marshaler
+	}{
+		{file: "public_input.json", value: new(PublicInput)},
+		{file: "execution_proof.json", value: new(ExecutionProof)},
+		{file: "signed_execution_proof.json", value: new(SignedExecutionProof)},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = bytes.TrimSpace(data)
+
+			if err := json.Unmarshal(data, tc.value); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			encoded, err := json.Marshal(tc.value)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			if !bytes.Equal(encoded, data) {
+				t.Errorf("round trip mismatch:\ngot  %s\nwant %s", encoded, data)
+			}
+		})
+	}
+}
+
+func TestSignedExecutionProofJSON(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "signed_execution_proof.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := new(SignedExecutionProof)
+	if err := json.Unmarshal(data, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	if proof.ValidatorIndex != 27017 {
+		t.Errorf("validator index: got %d, want 27017", proof.ValidatorIndex)
+	}
+
+	if len(proof.Signature) != 96 {
+		t.Errorf("signature length: got %d, want 96", len(proof.Signature))
+	}
+
+	if proof.Message.ProofType != 1 {
+		t.Errorf("proof type: got %d, want 1", proof.Message.ProofType)
+	}
+
+	assertBytes(t, "proof data", proof.Message.ProofData, "0x64756d6d792070726f6f66")
+	assertBytes(t, "new payload request root", proof.Message.PublicInput.NewPayloadRequestRoot, electraBlockFixtures[0].newPayloadRequestRoot)
+
+	// The SSZ encoding must round-trip too, as proofs are signed over their hash tree root
+	encoded, err := proof.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := new(SignedExecutionProof)
+	if err := decoded.UnmarshalSSZ(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	assertJSONEqual(t, mustMarshalJSON(t, decoded), data)
+}
+
+// mustMarshalJSON encodes value to JSON, failing the test on error.
+func mustMarshalJSON(t *testing.T, value any) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestBlindedBeaconBlockMissingFields(t *testing.T) {
+	body := func(block map[string]any) map[string]any {
+		return block["body"].(map[string]any)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(block map[string]any)
+	}{
+		{name: "body", mutate: func(block map[string]any) { delete(block, "body") }},
+		{name: "eth1_data", mutate: func(block map[string]any) { delete(body(block), "eth1_data") }},
+		{name: "sync_aggregate", mutate: func(block map[string]any) { delete(body(block), "sync_aggregate") }},
+		{name: "execution_payload_header", mutate: func(block map[string]any) { body(block)["execution_payload_header"] = nil }},
+		{name: "execution_requests", mutate: func(block map[string]any) { delete(body(block), "execution_requests") }},
+		{name: "null deposit request", mutate: func(block map[string]any) {
+			body(block)["execution_requests"].(map[string]any)["deposits"] = []any{nil}
+		}},
+		{name: "null attestation", mutate: func(block map[string]any) { body(block)["attestations"] = []any{nil} }},
+		{name: "attestation data", mutate: func(block map[string]any) {
+			delete(body(block)["attestations"].([]any)[0].(map[string]any), "data")
+		}},
+		{name: "checkpoint", mutate: func(block map[string]any) {
+			delete(body(block)["attestations"].([]any)[0].(map[string]any)["data"].(map[string]any), "target")
+		}},
+		{name: "proposer slashing header", mutate: func(block map[string]any) {
+			body(block)["proposer_slashings"] = []any{map[string]any{}}
+		}},
+		{name: "attester slashing attestation", mutate: func(block map[string]any) {
+			body(block)["attester_slashings"] = []any{map[string]any{}}
+		}},
+		{name: "voluntary exit message", mutate: func(block map[string]any) {
+			body(block)["voluntary_exits"] = []any{map[string]any{"signature": "0x"}}
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", electraBlockFixtures[0].file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var block map[string]any
+			if err := json.Unmarshal(data, &block); err != nil {
+				t.Fatal(err)
+			}
+
+			tc.mutate(block)
+
+			err = json.Unmarshal(mustMarshalJSON(t, block), new(BlindedBeaconBlock))
+			if !errors.Is(err, errMissingField) {
+				t.Fatalf("error: got %v, want %v", err, errMissingField)
+			}
+		})
+	}
+}
+
+func TestSignedBlindedBeaconBlockMissingMessage(t *testing.T) {
+	for _, data := range []string{`{"signature":"0x"}`, `{"message":null,"signature":"0x"}`, `null`} {
+		err := json.Unmarshal([]byte(data), new(SignedBlindedBeaconBlock))
+		if !errors.Is(err, errMissingField) {
+			t.Errorf("%s: got %v, want %v", data, err, errMissingField)
+		}
+	}
+}
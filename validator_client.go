@@ -15,10 +15,11 @@ import (
 type ValidatorClient struct {
 	baseURL    string
 	httpClient *http.Client
+	retry      retryPolicy
 }
 
-// NewValidatorClient creates a new validator client.
-func NewValidatorClient(baseURL string) *ValidatorClient {
+// NewValidatorClient creates a new validator client, retrying failed requests with retry.
+func NewValidatorClient(baseURL string, retry retryPolicy) *ValidatorClient {
 	// Ensure no trailing slash
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
@@ -27,13 +28,13 @@ func NewValidatorClient(baseURL string) *ValidatorClient {
 		httpClient: &http.Client{
 			Timeout: 12 * time.Second,
 		},
+		retry: retry,
 	}
 }
 
 // SignExecutionProof sends an execution proof to the validator client for signing.
+// BLS signatures are deterministic, so failed signing requests are retried.
 func (c *ValidatorClient) SignExecutionProof(ctx context.Context, proof *ExecutionProof) (*SignedExecutionProof, error) {
-	url := c.baseURL + "/eth/v2/validator/execution_proofs"
-
 	reqBody := &ExecutionProofRequest{
 		Data: proof,
 	}
@@ -43,6 +44,15 @@ func (c *ValidatorClient) SignExecutionProof(ctx context.Context, proof *Executi
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
+	return retryValue(ctx, c.retry, "sign_proof", func() (*SignedExecutionProof, error) {
+		return c.signExecutionProof(ctx, body)
+	})
+}
+
+// signExecutionProof makes a single attempt at signing a JSON encoded execution proof request.
+func (c *ValidatorClient) signExecutionProof(ctx context.Context, body []byte) (*SignedExecutionProof, error) {
+	url := c.baseURL + "/eth/v2/validator/execution_proofs"
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
@@ -52,7 +62,7 @@ func (c *ValidatorClient) SignExecutionProof(ctx context.Context, proof *Executi
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+		return nil, fmt.Errorf("send request: %w", transportError(err))
 	}
 	defer resp.Body.Close()
 
@@ -62,7 +72,7 @@ func (c *ValidatorClient) SignExecutionProof(ctx context.Context, proof *Executi
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var signedResp SignedExecutionProofResponse
@@ -76,3 +86,25 @@ func (c *ValidatorClient) SignExecutionProof(ctx context.Context, proof *Executi
 
 	return signedResp.Data, nil
 }
+
+// CheckHealth returns an error if the validator client is unreachable.
+// Validator clients expose no common health endpoint, so any HTTP answer
+// other than a server error counts as healthy.
+func (c *ValidatorClient) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/", nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// web3SignerExecutionProofType is the signing request type of execution proofs.
+const web3SignerExecutionProofType = "EXECUTION_PROOF"
+
+type (
+	// web3SignerSignRequest is the body of a Web3Signer eth2 signing request.
+	web3SignerSignRequest struct {
+		Type           string              `json:"type"`
+		ForkInfo       *web3SignerForkInfo `json:"fork_info"`
+		SigningRoot    string              `json:"signingRoot"`
+		ExecutionProof *ExecutionProof     `json:"execution_proof"`
+	}
+
+	web3SignerForkInfo struct {
+		Fork                  *web3SignerFork `json:"fork"`
+		GenesisValidatorsRoot string          `json:"genesis_validators_root"`
+	}
+
+	web3SignerFork struct {
+		PreviousVersion string `json:"previous_version"`
+		CurrentVersion  string `json:"current_version"`
+		Epoch           string `json:"epoch"`
+	}
+
+	// web3SignerSignResponse is the JSON response of a Web3Signer signing request.
+	web3SignerSignResponse struct {
+		Signature string `json:"signature"`
+	}
+)
+
+// Web3Signer signs execution proofs with keys held by a Web3Signer instance.
+// Proofs are signed by each configured public key in turn.
+type Web3Signer struct {
+	baseURL    string
+	httpClient *http.Client
+	retry      retryPolicy
+	indices    *validatorIndexCache
+	domain     *signingDomainProvider
+
+	mu      sync.Mutex
+	pubkeys [][]byte
+	next    int
+}
+
+// NewWeb3Signer creates a new Web3Signer client.
+// If pubkeys is empty, every key exposed by the Web3Signer instance is used.
+// indices resolves the validator indices of the keys, domain the signing domain.
+// Failed requests are retried with retry.
+func NewWeb3Signer(baseURL string, pubkeys [][]byte, indices *validatorIndexCache, domain *signingDomainProvider, retry retryPolicy) *Web3Signer {
+	// Ensure no trailing slash
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &Web3Signer{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 12 * time.Second,
+		},
+		retry:   retry,
+		indices: indices,
+		domain:  domain,
+		pubkeys: pubkeys,
+	}
+}
+
+// SignExecutionProof implements Signer.
+func (s *Web3Signer) SignExecutionProof(ctx context.Context, proof *ExecutionProof) (*SignedExecutionProof, error) {
+	pubkey, err := s.nextPublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+
+	validatorIndex, err := s.indices.get(ctx, pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("validator index of %#x: %w", pubkey, err)
+	}
+
+	fork, genesisValidatorsRoot, err := s.domain.forkInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fork info: %w", err)
+	}
+
+	signingRoot, err := s.domain.signingRoot(ctx, proof)
+	if err != nil {
+		return nil, fmt.Errorf("signing root: %w", err)
+	}
+
+	reqBody := &web3SignerSignRequest{
+		Type: web3SignerExecutionProofType,
+		ForkInfo: &web3SignerForkInfo{
+			Fork: &web3SignerFork{
+				PreviousVersion: fmt.Sprintf("%#x", fork.PreviousVersion),
+				CurrentVersion:  fmt.Sprintf("%#x", fork.CurrentVersion),
+				Epoch:           fmt.Sprintf("%d", fork.Epoch),
+			},
+			GenesisValidatorsRoot: fmt.Sprintf("%#x", genesisValidatorsRoot),
+		},
+		SigningRoot:    fmt.Sprintf("%#x", signingRoot),
+		ExecutionProof: proof,
+	}
+
+	signature, err := s.sign(ctx, pubkey, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedExecutionProof{
+		Message:        proof,
+		ValidatorIndex: validatorIndex,
+		Signature:      signature,
+	}, nil
+}
+
+// sign sends a signing request for pubkey and returns the decoded signature.
+// BLS signatures are deterministic, so failed signing requests are retried.
+func (s *Web3Signer) sign(ctx context.Context, pubkey []byte, reqBody *web3SignerSignRequest) ([]byte, error) {
+	url := fmt.Sprintf("%s/api/v1/eth2/sign/%#x", s.baseURL, pubkey)
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	return retryValue(ctx, s.retry, "web3signer_sign", func() ([]byte, error) {
+		return s.signOnce(ctx, url, body)
+	})
+}
+
+// signOnce makes a single attempt at a JSON encoded signing request to url.
+func (s *Web3Signer) signOnce(ctx context.Context, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", transportError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	// Web3Signer answers with JSON when asked to, older versions only with a plain hex string.
+	signatureHex := strings.TrimSpace(string(respBody))
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" {
+		var signResp web3SignerSignResponse
+		if err := json.Unmarshal(respBody, &signResp); err != nil {
+			return nil, fmt.Errorf("unmarshal response: %w", err)
+		}
+
+		signatureHex = signResp.Signature
+	}
+
+	signature, err := decodeHexBytes(signatureHex)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+
+	if len(signature) != 96 {
+		return nil, fmt.Errorf("invalid signature length: got %d, want 96", len(signature))
+	}
+
+	return signature, nil
+}
+
+// nextPublicKey returns the public key to sign the next proof with.
+func (s *Web3Signer) nextPublicKey(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pubkeys) == 0 {
+		pubkeys, err := s.getPublicKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pubkeys) == 0 {
+			return nil, errors.New("web3signer exposes no public key")
+		}
+
+		s.pubkeys = pubkeys
+	}
+
+	pubkey := s.pubkeys[s.next%len(s.pubkeys)]
+	s.next++
+
+	return pubkey, nil
+}
+
+// getPublicKeys lists the BLS public keys available in the Web3Signer instance.
+func (s *Web3Signer) getPublicKeys(ctx context.Context) ([][]byte, error) {
+	return retryValue(ctx, s.retry, "web3signer_public_keys", func() ([][]byte, error) {
+		return s.getPublicKeysOnce(ctx)
+	})
+}
+
+// getPublicKeysOnce makes a single attempt at listing the public keys.
+func (s *Web3Signer) getPublicKeysOnce(ctx context.Context) ([][]byte, error) {
+	url := s.baseURL + "/api/v1/eth2/publicKeys"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", transportError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var hexKeys []string
+	if err := json.Unmarshal(respBody, &hexKeys); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return parsePublicKeys(hexKeys)
+}
+
+// parsePublicKeys decodes 0x-prefixed hex BLS public keys.
+func parsePublicKeys(hexKeys []string) ([][]byte, error) {
+	pubkeys := make([][]byte, 0, len(hexKeys))
+	for _, hexKey := range hexKeys {
+		hexKey = strings.TrimSpace(hexKey)
+		if hexKey == "" {
+			continue
+		}
+
+		pubkey, err := decodeHexBytes(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("decode public key %s: %w", hexKey, err)
+		}
+
+		if len(pubkey) != 48 {
+			return nil, fmt.Errorf("invalid public key length for %s: got %d, want 48", hexKey, len(pubkey))
+		}
+
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	return pubkeys, nil
+}
+
+// CheckHealth returns an error if the Web3Signer instance is not up.
+func (s *Web3Signer) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/upcheck", nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	testPubkey                = bytes.Repeat([]byte{0xaa}, 48)
+	testSignature             = bytes.Repeat([]byte{0xbb}, 96)
+	testGenesisValidatorsRoot = bytes.Repeat([]byte{0x11}, 32)
+)
+
+// newTestBeaconNode serves the genesis, fork and validator endpoints the signers query.
+func newTestBeaconNode(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":{"genesis_time":"1606824023","genesis_validators_root":"%#x","genesis_fork_version":"0x00000000"}}`, testGenesisValidatorsRoot)
+	})
+	mux.HandleFunc("GET /eth/v1/beacon/states/head/fork", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"previous_version":"0x04000000","current_version":"0x05000000","epoch":"364032"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /eth/v1/beacon/states/head/validators/%#x", testPubkey), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"index":"42"}}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestWeb3Signer creates a Web3Signer signing with testPubkey through handler.
+func newTestWeb3Signer(t *testing.T, handler http.HandlerFunc) *Web3Signer {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	beaconClient := NewBeaconClient(newTestBeaconNode(t).URL, newRetryPolicy(1))
+
+	domain, err := newSigningDomainProvider(beaconClient, defaultExecutionProofDomainType, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indices, err := newValidatorIndexCache(beaconClient, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewWeb3Signer(server.URL+"/", [][]byte{testPubkey}, indices, domain, newRetryPolicy(1))
+}
+
+func testExecutionProof() *ExecutionProof {
+	return &ExecutionProof{
+		ProofData: []byte{0x01, 0x02, 0x03},
+		ProofType: 1,
+		PublicInput: &PublicInput{
+			NewPayloadRequestRoot: bytes.Repeat([]byte{0x22}, 32),
+		},
+	}
+}
+
+func TestWeb3SignerSignExecutionProof(t *testing.T) {
+	proof := testExecutionProof()
+
+	domain, err := computeDomain([4]byte{0x0d}, []byte{0x05, 0x00, 0x00, 0x00}, testGenesisValidatorsRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingRoot, err := computeSigningRoot(proof, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantBody := fmt.Sprintf(`{
+		"type": "EXECUTION_PROOF",
+		"fork_info": {
+			"fork": {"previous_version": "0x04000000", "current_version": "0x05000000", "epoch": "364032"},
+			"genesis_validators_root": "%#x"
+		},
+		"signingRoot": "%#x",
+		"execution_proof": {
+			"proof_data": "0x010203",
+			"proof_type": "1",
+			"public_input": {"new_payload_request_root": "%#x"}
+		}
+	}`, testGenesisValidatorsRoot, signingRoot, proof.PublicInput.NewPayloadRequestRoot)
+
+	for _, tc := range []struct {
+		name        string
+		contentType string
+		response    string
+	}{
+		{name: "json", contentType: "application/json; charset=utf-8", response: fmt.Sprintf(`{"signature":"%#x"}`, testSignature)},
+		{name: "plain text", contentType: "text/plain", response: fmt.Sprintf("%#x\n", testSignature)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			signer := newTestWeb3Signer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("method: got %s, want POST", r.Method)
+				}
+
+				if want := fmt.Sprintf("/api/v1/eth2/sign/%#x", testPubkey); r.URL.Path != want {
+					t.Errorf("path: got %s, want %s", r.URL.Path, want)
+				}
+
+				if got := r.Header.Get("Content-Type"); got != "application/json" {
+					t.Errorf("content type: got %s, want application/json", got)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				assertJSONEqual(t, body, []byte(wantBody))
+
+				w.Header().Set("Content-Type", tc.contentType)
+				fmt.Fprint(w, tc.response)
+			})
+
+			signed, err := signer.SignExecutionProof(context.Background(), proof)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if signed.Message != proof {
+				t.Error("signed proof does not wrap the proof")
+			}
+
+			if signed.ValidatorIndex != 42 {
+				t.Errorf("validator index: got %d, want 42", signed.ValidatorIndex)
+			}
+
+			if !bytes.Equal(signed.Signature, testSignature) {
+				t.Errorf("signature: got %#x, want %#x", signed.Signature, testSignature)
+			}
+		})
+	}
+}
+
+func TestWeb3SignerSignExecutionProofErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		status   int
+		response string
+		wantErr  string
+	}{
+		{name: "bad request", status: http.StatusBadRequest, response: "bad request format", wantErr: "unexpected status code 400: bad request format"},
+		{name: "unknown key", status: http.StatusNotFound, response: "public key not found", wantErr: "unexpected status code 404: public key not found"},
+		{name: "slashing protection", status: http.StatusPreconditionFailed, response: "signing operation failed due to slashing protection rules", wantErr: "unexpected status code 412"},
+		{name: "internal error", status: http.StatusInternalServerError, response: "internal error", wantErr: "unexpected status code 500"},
+		{name: "short signature", status: http.StatusOK, response: "0xbbbb", wantErr: "invalid signature length: got 2, want 96"},
+		{name: "invalid hex", status: http.StatusOK, response: "0xzz", wantErr: "decode signature"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			signer := newTestWeb3Signer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.response)
+			})
+
+			_, err := signer.SignExecutionProof(context.Background(), testExecutionProof())
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWeb3SignerRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "rate limited", statuses: []int{http.StatusTooManyRequests, http.StatusOK}, wantAttempts: 2},
+		{name: "unavailable", statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}, wantAttempts: 3},
+		{name: "out of attempts", statuses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable}, wantAttempts: 3, wantErr: errServerError},
+		{name: "not retryable", statuses: []int{http.StatusBadRequest}, wantAttempts: 1, wantErr: errBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			signer := newTestWeb3Signer(t, func(w http.ResponseWriter, r *http.Request) {
+				attempt := int(attempts.Add(1))
+				if attempt > len(tc.statuses) {
+					t.Errorf("unexpected attempt %d", attempt)
+					return
+				}
+
+				w.WriteHeader(tc.statuses[attempt-1])
+				fmt.Fprintf(w, "%#x", testSignature)
+			})
+			signer.retry = newRetryPolicy(3)
+			signer.retry.minBackoff = time.Millisecond
+			signer.retry.maxBackoff = time.Millisecond
+
+			signed, err := signer.SignExecutionProof(context.Background(), testExecutionProof())
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("error: got %v, want %v", err, tc.wantErr)
+			}
+
+			if err == nil && !bytes.Equal(signed.Signature, testSignature) {
+				t.Errorf("signature: got %#x, want %#x", signed.Signature, testSignature)
+			}
+
+			if got := int(attempts.Load()); got != tc.wantAttempts {
+				t.Errorf("attempts: got %d, want %d", got, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWeb3SignerPublicKeys(t *testing.T) {
+	signer := newTestWeb3Signer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/eth2/publicKeys":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `["%#x"]`, testPubkey)
+
+		case fmt.Sprintf("/api/v1/eth2/sign/%#x", testPubkey):
+			fmt.Fprintf(w, "%#x", testSignature)
+
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	signer.pubkeys = nil
+
+	signed, err := signer.SignExecutionProof(context.Background(), testExecutionProof())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(signed.Signature, testSignature) {
+		t.Errorf("signature: got %#x, want %#x", signed.Signature, testSignature)
+	}
+}
+
+// assertJSONEqual fails the test if got and want are not the same JSON value.
+func assertJSONEqual(t *testing.T, got, want []byte) {
+	t.Helper()
+
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("unmarshal %s: %v", got, err)
+	}
+
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("unmarshal %s: %v", want, err)
+	}
+
+	gotJSON, _ := json.Marshal(gotValue)
+	wantJSON, _ := json.Marshal(wantValue)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Errorf("JSON mismatch:\ngot  %s\nwant %s", gotJSON, wantJSON)
+	}
+}